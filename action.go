@@ -0,0 +1,90 @@
+// Copyright © 2015 Victor Antonovich <victor@antonovich.me>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Action executed after template outputs updating
+type Action interface {
+	// Returns key to check actions are the same
+	Key() string
+	// Returns action description
+	String() string
+	// Execute action in given context
+	Execute(ctx *ActionContext) error
+}
+
+// Action execution context
+type ActionContext struct {
+	// Templates updated before action execution
+	Templates []*Template
+	// Environment variables describing templates updated
+	Env []string
+	// Action execution timeout
+	Timeout time.Duration
+	// Time to wait for command to exit after termination on timeout before killing it
+	KillTimeout time.Duration
+}
+
+// Execute command with context environment variables and timeouts
+func (c Command) Execute(ctx *ActionContext) error {
+	c.Env = ctx.Env
+	return Execute(c, ctx.Timeout, ctx.KillTimeout)
+}
+
+// Action to send signal to process with id read from pid file
+type SignalAction struct {
+	// Process id file path
+	PidFile string
+	// Signal to send
+	Signal os.Signal
+}
+
+// Returns key to check signal actions are the same
+func (a *SignalAction) Key() string {
+	return fmt.Sprintf("signal %d %s", a.Signal, normCommandPath(a.PidFile))
+}
+
+// Returns shell command equivalent to signal action
+func (a *SignalAction) String() string {
+	return fmt.Sprintf("kill -%s $(cat %s)", SignalName(a.Signal), a.PidFile)
+}
+
+// Send signal to process from pid file, checking the process exists
+func (a *SignalAction) Execute(_ *ActionContext) error {
+	data, err := ioutil.ReadFile(a.PidFile)
+	if err != nil {
+		return fmt.Errorf("can't read pid file: %v", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return fmt.Errorf("invalid pid in %s: %q", a.PidFile, strings.TrimSpace(string(data)))
+	}
+	p, err := findProcess(pid)
+	if err != nil {
+		return fmt.Errorf("process %d from %s is not running (stale pid file?): %v", pid, a.PidFile, err)
+	}
+	if err := p.Signal(a.Signal); err != nil {
+		return fmt.Errorf("can't send %s to process %d from %s: %v", SignalName(a.Signal), pid, a.PidFile, err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignalAction(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kube-template")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	pidFile := filepath.Join(dir, "test.pid")
+	a := &SignalAction{PidFile: pidFile, Signal: syscall.SIGTERM}
+
+	// Missing pid file
+	require.Error(t, a.Execute(&ActionContext{}))
+
+	// Invalid pid file
+	require.NoError(t, ioutil.WriteFile(pidFile, []byte("nginx\n"), 0644))
+	require.Error(t, a.Execute(&ActionContext{}))
+
+	cmd := exec.Command("sleep", "30")
+	require.NoError(t, cmd.Start())
+	defer func() { _ = cmd.Process.Kill() }()
+	pid := strconv.Itoa(cmd.Process.Pid)
+
+	// Running process
+	require.NoError(t, ioutil.WriteFile(pidFile, []byte(pid+"\n"), 0644))
+	require.NoError(t, a.Execute(&ActionContext{}))
+	err = cmd.Wait()
+	require.Error(t, err)
+	require.Equal(t, syscall.SIGTERM, exitSignal(err.(*exec.ExitError).ProcessState))
+
+	// Stale pid file
+	err = a.Execute(&ActionContext{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not running")
+}
@@ -20,12 +20,20 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang/glog"
 )
 
+// Hook called after templates processing run with templates which outputs were updated
+type runHook func(app *App, updated []*Template)
+
 type App struct {
 	// Stop channel
 	stopCh chan struct{}
@@ -35,9 +43,46 @@ type App struct {
 	// Do not write template output flag
 	dryRun bool
 
+	// Show template output diffs flag
+	diff bool
+	// Colorize template output diffs flag
+	diffColor bool
+	// Templates changed and commands to run during last run, in diff mode
+	diffTemplates []string
+	diffCommands  []string
+
+	// Last run failed flag
+	runFailed bool
+	// Errors and templates processing results of last run
+	errors  []*TemplateError
+	results []*TemplateResult
+
+	// Lock protecting templates processing status
+	statusLock sync.RWMutex
+	// Start time of templates processing run in progress, zero if none
+	runStarted time.Time
+
+	// Run once mode flag
+	runOnce bool
+
+	// Failed actions pending for retry
+	pending []*scheduledAction
+
+	// Hook called after each templates processing run
+	onRun runHook
+
+	// Leader elector, nil if leader election is disabled
+	leader *LeaderElector
+
 	// Template output update period
 	updatePeriod time.Duration
 
+	// HTTP(S) template sources poll period
+	sourcePollPeriod time.Duration
+
+	// Kubernetes objects updates notification channel
+	updateCh <-chan struct{}
+
 	// Dependency manager
 	dm *DependencyManager
 
@@ -57,6 +102,8 @@ func newApp(cfg *Config) (*App, error) {
 
 	// Create dependency manager
 	dm := newDependencyManager(client)
+	dm.strict = cfg.Strict
+	dm.namespace = cfg.Namespace
 
 	// Add all configured templates
 	templates, err := newTemplatesFromConfig(cfg, dm)
@@ -64,15 +111,36 @@ func newApp(cfg *Config) (*App, error) {
 		return nil, err
 	}
 
+	// Create leader elector, if enabled
+	var leader *LeaderElector
+	if cfg.LeaderElection.Enabled {
+		if leader, err = newLeaderElector(cfg.LeaderElection, client.kubeClient); err != nil {
+			close(stopCh)
+			return nil, err
+		}
+	}
+
 	doneCh := make(chan struct{})
 
+	// Server polling is a fallback if not watching for updates
+	var updatePeriod time.Duration
+	if cfg.PollingEnabled() {
+		updatePeriod = cfg.PollPeriod
+	}
+
 	return &App{
-		stopCh:       stopCh,
-		doneCh:       doneCh,
-		dm:           dm,
-		templates:    templates,
-		dryRun:       cfg.DryRun,
-		updatePeriod: cfg.PollPeriod,
+		stopCh:           stopCh,
+		doneCh:           doneCh,
+		dm:               dm,
+		templates:        templates,
+		dryRun:           cfg.DryRun,
+		runOnce:          cfg.RunOnce,
+		diff:             cfg.Diff,
+		diffColor:        cfg.Diff && isColorSupported(os.Stdout),
+		updatePeriod:     updatePeriod,
+		sourcePollPeriod: cfg.SourcePollPeriod,
+		updateCh:         client.Updates(),
+		leader:           leader,
 	}, nil
 }
 
@@ -83,82 +151,525 @@ func (app *App) Start() {
 
 	defer glog.V(1).Infoln("templates processing stopped")
 
+	var leadingCh <-chan struct{}
+	if app.leader != nil {
+		// Run leader election until stopped, releasing lease before exit
+		leaderDoneCh := make(chan struct{})
+		go func() {
+			defer close(leaderDoneCh)
+			app.leader.Run(app.stopCh)
+		}()
+		defer func() { <-leaderDoneCh }()
+		leadingCh = app.leader.Leading()
+	}
+
 	// Initial templates processing run
 	app.Run()
 
-	if app.updatePeriod.Nanoseconds() <= 0 {
-		<-app.stopCh
-		return
+	var pollCh <-chan time.Time
+	if app.updatePeriod.Nanoseconds() > 0 {
+		pollTicker := time.NewTicker(app.updatePeriod)
+		defer pollTicker.Stop()
+		pollCh = pollTicker.C
 	}
 
+	var sourcePollCh <-chan time.Time
+	if app.sourcePollPeriod.Nanoseconds() > 0 && app.hasPolledSources() {
+		sourcePollTicker := time.NewTicker(app.sourcePollPeriod)
+		defer sourcePollTicker.Stop()
+		sourcePollCh = sourcePollTicker.C
+	}
+
+	var waitCh <-chan time.Time
+
 	for {
 		select {
 		case <-app.stopCh:
 			return
-		case <-time.After(app.updatePeriod):
+		case <-pollCh:
+			// Objects updates are not watched, so check all template sources
+			app.reloadTemplates(time.Now(), func(_ *Template) bool { return true })
+			app.Run()
+		case <-sourcePollCh:
+			app.reloadTemplates(time.Now(), func(t *Template) bool { return t.source.polled() })
+		case <-leadingCh:
+			// Write outputs rendered while standing by
+			glog.V(1).Infoln("leadership acquired, processing templates")
 			app.Run()
+		case <-app.updateCh:
+			// Start or extend quiescence timers of affected templates
+			now := time.Now()
+			changes := app.dm.client.Changes()
+			app.reloadTemplates(now, func(t *Template) bool { return t.source.affectedBy(changes) })
+			for _, t := range app.templates {
+				if t.affectedBy(changes) {
+					t.quiescence.tick(now)
+				} else {
+					glog.V(4).Infof("template not affected by updates: %s", t.name)
+				}
+			}
+		case <-waitCh:
+		}
+		// Process templates with expired quiescence timers
+		now := time.Now()
+		var templates []*Template
+		for _, t := range app.templates {
+			if t.quiescence.isDue(now) {
+				templates = append(templates, t)
+			}
+		}
+		if len(templates) > 0 {
+			app.run(templates)
+		}
+		// Retry failed commands, if due
+		app.retryPending(time.Now())
+		// Wait for nearest quiescence timer expiration or command retry, if any
+		waitCh = nil
+		due := app.nextRetry()
+		for _, t := range app.templates {
+			if !t.quiescence.due.IsZero() && (due.IsZero() || t.quiescence.due.Before(due)) {
+				due = t.quiescence.due
+			}
+		}
+		if !due.IsZero() {
+			waitCh = time.After(due.Sub(now))
 		}
 	}
 }
 
-func (app *App) RunOnce() {
+// Check any template source should be polled for changes
+func (app *App) hasPolledSources() bool {
+	for _, t := range app.templates {
+		if t.source != nil && t.source.polled() {
+			return true
+		}
+	}
+	return false
+}
+
+// Reload templates matching given filter from their sources, if changed,
+// and start quiescence timers of reloaded ones
+func (app *App) reloadTemplates(now time.Time, filter func(t *Template) bool) {
+	for _, t := range app.templates {
+		if t.source == nil || !filter(t) {
+			continue
+		}
+		reloaded, err := t.reload()
+		if err != nil {
+			glog.Errorf("can't reload template %s from %s: %v", t.name, t.source, err)
+			continue
+		}
+		if reloaded {
+			glog.V(1).Infof("template source changed, reloaded: %s", t.name)
+			t.quiescence.tick(now)
+		}
+	}
+}
+
+// Process all templates once, waiting for failed commands retries.
+// Returns aggregated error of templates processing, if any.
+func (app *App) RunOnce() error {
 	glog.V(1).Infoln("run once templates processing...")
 	app.Run()
+	// Wait for failed commands retries
+	for len(app.pending) > 0 {
+		time.Sleep(time.Until(app.nextRetry()))
+		app.retryPending(time.Now())
+	}
 	glog.V(1).Infoln("templates processed")
+	return app.err()
+}
+
+// Process all templates. Returns aggregated error of templates processing, if any.
+func (app *App) Run() error {
+	app.run(app.templates)
+	return app.err()
 }
 
-func (app *App) Run() {
-	// Commands to execute are stored in list instead of map to ensure correct execution order
-	var commands []string
-	commandTimeouts := make(map[string]time.Duration)
+// Environment variables passed to commands
+const (
+	EnvChangedOutputs = "KUBE_TEMPLATE_CHANGED_OUTPUTS"
+	EnvTemplates      = "KUBE_TEMPLATE_TEMPLATES"
+	EnvDryRun         = "KUBE_TEMPLATE_DRY_RUN"
+)
+
+// Maximum delay between failed action retries
+const MaxCommandBackoff = 5 * time.Minute
+
+// Action scheduled for execution after templates processing
+type scheduledAction struct {
+	action      Action
+	timeout     time.Duration
+	killTimeout time.Duration
+	// Templates updated before action execution, to roll back if action fails
+	templates []*Template
+	// Action key
+	key string
+	// Action failure handling settings
+	retries   int
+	backoff   time.Duration
+	onFailure string
+	// Failed action execution attempts
+	attempts int
+	// Time of next action execution attempt, if failed
+	due time.Time
+}
+
+func (app *App) run(templates []*Template) {
+	// Actions to execute are stored in list instead of map to ensure correct execution order
+	var actions []*scheduledAction
+	scheduled := make(map[string]*scheduledAction)
+	// Templates which outputs were updated
+	var updatedTemplates []*Template
+	app.setRunStarted(time.Now())
+	defer app.setRunStarted(time.Time{})
+	app.runFailed = false
+	app.errors, app.results = nil, nil
+	app.diffTemplates = nil
 	// Flush cached dependencies
 	app.dm.flushCachedDependencies()
+	if app.standby() {
+		app.renderStandby(templates)
+		// No outputs are updated, so in exec mode child process is started but not reloaded
+		if app.onRun != nil {
+			app.onRun(app, nil)
+		}
+		return
+	}
 	// Process templates
-	for _, t := range app.templates {
+	for _, t := range templates {
+		t.quiescence.reset()
 		glog.V(2).Infof("processing template: %s", t.name)
-		if updated, err := t.Process(app.dryRun); err == nil {
+		lastOutput := t.lastOutput
+		result := &TemplateResult{Template: t.name, Path: t.desc.Path, Output: t.desc.Output,
+			Status: TemplateStatusUnchanged, t: t}
+		app.results = append(app.results, result)
+		started := time.Now()
+		updated, err := t.Process(app.dryRun)
+		observeRender(t, started, updated, err)
+		app.processed(t, time.Now(), updated, err)
+		if err == nil {
 			if updated {
+				result.Status = TemplateStatusUpdated
 				if !app.dryRun {
 					glog.V(2).Infof("template output updated: %s", t.name)
+					updatedTemplates = append(updatedTemplates, t)
+				} else if app.diff {
+					app.printDiff(t, lastOutput)
 				} else {
 					fmt.Printf("(dry-run) %s:\n%s", t.name, t.lastOutput)
 				}
-				if cmd := t.desc.Command; len(cmd) > 0 {
-					// Normalize command path, if applicable
-					if _, err := os.Stat(cmd); err == nil {
-						if c, err := NormPath(cmd); err == nil {
-							cmd = c
+				for _, action := range t.desc.actions() {
+					// Check template action is already in list of actions to execute
+					key := action.Key()
+					sc, found := scheduled[key]
+					if !found {
+						if sc = app.removePending(key); sc != nil {
+							glog.V(4).Infof("template %s: scheduled pending action: %q", t.name, action.String())
+							sc.attempts = 0
+						} else {
+							glog.V(4).Infof("template %s: scheduled action: %q", t.name, action.String())
+							sc = &scheduledAction{
+								action:      action,
+								timeout:     t.desc.CommandTimeout,
+								killTimeout: t.desc.CommandKillTimeout,
+								key:         key,
+								retries:     t.desc.Retries,
+								backoff:     t.desc.Backoff,
+								onFailure:   t.desc.OnFailure,
+							}
 						}
-					}
-					// Check template command is already in list of commands to execute
-					if !IsPresent(commands, cmd) {
-						glog.V(4).Infof("template %s: scheduled command: %q", t.name, cmd)
-						commands = append(commands, cmd)
-						commandTimeouts[cmd] = t.desc.CommandTimeout
+						scheduled[key] = sc
+						actions = append(actions, sc)
 					} else {
-						glog.V(4).Infof("template %s: command already scheduled: %q", t.name, cmd)
+						glog.V(4).Infof("template %s: action already scheduled: %q", t.name, action.String())
 					}
+					sc.addTemplate(t)
 				}
 			} else {
 				glog.V(2).Infof("template output not changed: %s", t.name)
 			}
 		} else {
-			glog.Errorf("can't render %v", err)
+			glog.Error(err)
+			app.addError(t, err)
+			app.runFailed = true
 		}
 	}
-	// Execute commands for templates
-	for _, cmd := range commands {
+	if app.diff {
+		app.diffCommands = nil
+		for _, sc := range actions {
+			app.diffCommands = append(app.diffCommands, sc.action.String())
+		}
+		return
+	}
+	// Execute actions for templates
+	for _, sc := range actions {
 		if !app.dryRun {
-			glog.V(4).Infof("executing: %q", cmd)
-			if err := Execute(cmd, commandTimeouts[cmd]); err == nil {
-				glog.V(4).Infof("executed: %q", cmd)
-			} else {
-				glog.Errorf("command %q: %v", cmd, err)
+			app.execute(sc)
+		} else {
+			fmt.Printf("(dry-run) executing: %q, env: %q\n", sc.action.String(), sc.env(app.dryRun))
+		}
+	}
+	if app.onRun != nil {
+		app.onRun(app, updatedTemplates)
+	}
+}
+
+// Check leader election is enabled but leadership is not held
+func (app *App) standby() bool {
+	return app.leader != nil && !app.leader.IsLeader()
+}
+
+// Render given templates without writing outputs or executing commands,
+// keeping Kubernetes objects informers warm while not leading.
+// Successfully rendered templates are considered ready, so standby replica is ready to take over.
+func (app *App) renderStandby(templates []*Template) {
+	for _, t := range templates {
+		t.quiescence.reset()
+		glog.V(2).Infof("rendering template while not leading: %s", t.name)
+		_, err := t.Render()
+		if err != nil {
+			err = newTemplateError(t, ErrorClassRender, err)
+			glog.Error(err)
+		} else {
+			t.rendered = true
+		}
+		app.processed(t, time.Now(), false, err)
+	}
+}
+
+// Set start time of templates processing run in progress
+func (app *App) setRunStarted(t time.Time) {
+	app.statusLock.Lock()
+	defer app.statusLock.Unlock()
+	app.runStarted = t
+}
+
+// Check all templates were successfully processed at least once
+func (app *App) allRendered() bool {
+	for _, t := range app.templates {
+		if !t.rendered {
+			return false
+		}
+	}
+	return true
+}
+
+// Execute scheduled action, handling its failure
+func (app *App) execute(sc *scheduledAction) {
+	cmd := sc.action.String()
+	glog.V(4).Infof("executing: %q", cmd)
+	started := time.Now()
+	err := sc.action.Execute(&ActionContext{
+		Templates:   sc.templates,
+		Env:         sc.env(app.dryRun),
+		Timeout:     sc.timeout,
+		KillTimeout: sc.killTimeout,
+	})
+	observeCommand(sc.action, started, err)
+	app.executed(sc.templates, sc.action, err)
+	if err == nil {
+		glog.V(4).Infof("executed: %q", cmd)
+		return
+	}
+	glog.Errorf("action %q: %v", cmd, err)
+	sc.attempts++
+	if sc.attempts <= sc.retries || (sc.onFailure == OnFailureRetry && !app.runOnce) {
+		// Keep action pending until it succeeds
+		backoff := sc.backoff
+		if backoff <= 0 {
+			backoff = DefaultCommandBackoff
+		}
+		for i := 1; i < sc.attempts && backoff < MaxCommandBackoff; i++ {
+			backoff *= 2
+		}
+		if backoff > MaxCommandBackoff {
+			backoff = MaxCommandBackoff
+		}
+		sc.due = time.Now().Add(backoff)
+		app.pending = append(app.pending, sc)
+		glog.Warningf("action %q: retrying in %v (attempt %d)", cmd, backoff, sc.attempts+1)
+		return
+	}
+	switch sc.onFailure {
+	case OnFailureIgnore:
+		glog.Warningf("action %q: giving up, template outputs kept", cmd)
+		return
+	case OnFailureRetry:
+		glog.Warningf("action %q: giving up", cmd)
+	default:
+		app.rollback(sc.templates)
+	}
+	for _, t := range sc.templates {
+		app.addError(t, newTemplateError(t, ErrorClassCommand, err))
+	}
+}
+
+// Execute pending actions due at given time
+func (app *App) retryPending(now time.Time) {
+	if app.standby() {
+		// Keep actions pending until leadership is acquired
+		return
+	}
+	var due []*scheduledAction
+	for _, sc := range app.pending {
+		if !now.Before(sc.due) {
+			due = append(due, sc)
+		}
+	}
+	for _, sc := range due {
+		app.removePending(sc.key)
+		app.execute(sc)
+	}
+}
+
+// Returns time of nearest pending action retry, zero if no actions pending or not leading
+func (app *App) nextRetry() time.Time {
+	var due time.Time
+	if app.standby() {
+		return due
+	}
+	for _, sc := range app.pending {
+		if due.IsZero() || sc.due.Before(due) {
+			due = sc.due
+		}
+	}
+	return due
+}
+
+// Remove pending action with given key, returns removed action if found
+func (app *App) removePending(key string) *scheduledAction {
+	for i, sc := range app.pending {
+		if sc.key == key {
+			app.pending = append(app.pending[:i], app.pending[i+1:]...)
+			return sc
+		}
+	}
+	return nil
+}
+
+// Add template updated before action execution, if not added yet
+func (sc *scheduledAction) addTemplate(t *Template) {
+	for _, st := range sc.templates {
+		if st == t {
+			return
+		}
+	}
+	sc.templates = append(sc.templates, t)
+}
+
+// Returns environment variables for scheduled action: template ones, merged
+// in templates order, and ones describing templates updated
+func (sc *scheduledAction) env(dryRun bool) []string {
+	return commandEnv(sc.templates, dryRun)
+}
+
+// Returns environment variables for command executed for given updated templates.
+// Template outputs and paths are separated by newlines, so they can contain spaces.
+func commandEnv(templates []*Template, dryRun bool) []string {
+	vars := make(map[string]string)
+	var outputs, paths []string
+	for _, t := range templates {
+		for k, v := range t.desc.Env {
+			if prev, found := vars[k]; found && prev != v {
+				glog.Warningf("template %s: overriding env variable %s", t.name, k)
 			}
+			vars[k] = v
+		}
+		outputs = append(outputs, t.desc.Output)
+		paths = append(paths, t.desc.Path)
+	}
+	vars[EnvChangedOutputs] = strings.Join(outputs, "\n")
+	vars[EnvTemplates] = strings.Join(paths, "\n")
+	vars[EnvDryRun] = strconv.FormatBool(dryRun)
+	return envList(vars)
+}
+
+// Convert given environment variables map to sorted list of 'key=value' strings
+func envList(vars map[string]string) []string {
+	env := make([]string, 0, len(vars))
+	for k, v := range vars {
+		env = append(env, k+"="+v)
+	}
+	sort.Strings(env)
+	return env
+}
+
+// Roll back outputs of given templates
+func (app *App) rollback(templates []*Template) {
+	for _, t := range templates {
+		if err := t.Rollback(); err == nil {
+			glog.Warningf("template output rolled back: %s", t.name)
 		} else {
-			fmt.Printf("(dry-run) executing: %q\n", cmd)
+			glog.Errorf("can't roll back template output: %s: %v", t.name, err)
+		}
+	}
+}
+
+// Print diff between given last output of template and current one
+func (app *App) printDiff(t *Template, lastOutput string) {
+	app.diffTemplates = append(app.diffTemplates, t.name)
+	fromFile := t.desc.Output
+	if t.kubeOutput != nil {
+		if lastOutput == "" {
+			fromFile = os.DevNull
 		}
+	} else if _, err := os.Stat(fromFile); os.IsNotExist(err) {
+		fromFile = os.DevNull
 	}
+	diff, err := unifiedDiff(lastOutput, t.lastOutput, fromFile, t.desc.Path)
+	if err != nil {
+		glog.Errorf("can't diff %s: %v", t.name, err)
+		app.runFailed = true
+		return
+	}
+	if app.diffColor {
+		diff = colorizeDiff(diff)
+	}
+	fmt.Print(diff)
+}
+
+// Print summary of templates changed and commands to run during last run.
+// Returns true if any template output changed.
+func (app *App) printDiffSummary(w io.Writer) bool {
+	if len(app.diffTemplates) == 0 {
+		fmt.Fprintln(w, "no template outputs would change")
+		return false
+	}
+	fmt.Fprintf(w, "%d template output(s) would change: %s\n", len(app.diffTemplates),
+		strings.Join(app.diffTemplates, ", "))
+	for _, cmd := range app.diffCommands {
+		fmt.Fprintf(w, "command would run: %q\n", cmd)
+	}
+	return true
+}
+
+// Template rendering quiescence timer, used to collapse a series
+// of Kubernetes objects updates into a single template rendering
+type quiescence struct {
+	wait WaitConfig
+	// Time to render template if no more updates come, zero if no updates pending
+	due time.Time
+	// Time to render template regardless of further updates
+	deadline time.Time
+}
+
+func (q *quiescence) tick(now time.Time) {
+	if q.due.IsZero() {
+		q.deadline = now.Add(q.wait.Max)
+	}
+	q.due = now.Add(q.wait.Min)
+	if q.due.After(q.deadline) {
+		q.due = q.deadline
+	}
+}
+
+func (q *quiescence) isDue(now time.Time) bool {
+	return !q.due.IsZero() && !now.Before(q.due)
+}
+
+func (q *quiescence) reset() {
+	q.due, q.deadline = time.Time{}, time.Time{}
 }
 
 func (app *App) Stop() {
@@ -4,11 +4,17 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/kubernetes/pkg/controller/testutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
 
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestAppRunOnce(t *testing.T) {
@@ -45,7 +51,7 @@ func TestAppRunOnce(t *testing.T) {
 	defer close(stopCh)
 	defer close(doneCh)
 
-	tc, err := newClient(fakeClient, stopCh, false)
+	tc, err := newClient(fakeClient, nil, stopCh, false)
 	require.NoError(t, err)
 
 	dm := newDependencyManager(tc)
@@ -70,3 +76,317 @@ func TestAppRunOnce(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, string(expected), string(actual))
 }
+
+func TestQuiescence(t *testing.T) {
+	q := quiescence{wait: WaitConfig{Min: 2 * time.Second, Max: 5 * time.Second}}
+	now := time.Now()
+	require.False(t, q.isDue(now))
+
+	q.tick(now)
+	require.False(t, q.isDue(now.Add(time.Second)))
+	require.True(t, q.isDue(now.Add(2*time.Second)))
+
+	// Updates keep coming, rendering is postponed up to maximum wait time
+	q.tick(now.Add(time.Second))
+	q.tick(now.Add(2 * time.Second))
+	require.False(t, q.isDue(now.Add(3*time.Second)))
+	q.tick(now.Add(4 * time.Second))
+	require.True(t, q.isDue(now.Add(5*time.Second)))
+
+	q.reset()
+	require.False(t, q.isDue(now.Add(10*time.Second)))
+
+	// No wait configured, render immediately
+	q = quiescence{}
+	q.tick(now)
+	require.True(t, q.isDue(now))
+}
+
+func TestAppDiff(t *testing.T) {
+	f := newTestFixture(t, fake.NewSimpleClientset(testutil.NewPod("pod1", "host1")), false)
+	defer f.Close()
+
+	template := filepath.Join(f.dir, "pods.tmpl")
+	output := filepath.Join(f.dir, "pods.txt")
+	require.NoError(t, ioutil.WriteFile(template, []byte("pods: {{len (pods)}}\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(output, []byte("pods: 0\n"), 0644))
+
+	cmd := newCmd()
+	err := cmd.ParseFlags([]string{
+		"--diff",
+		fmt.Sprintf("--template=%s:%s:true", template, output),
+	})
+	require.NoError(t, err)
+
+	cfg, err := newConfig(cmd)
+	require.NoError(t, err)
+	require.True(t, cfg.DryRun)
+	require.True(t, cfg.RunOnce)
+
+	templates, err := newTemplatesFromConfig(cfg, f.dm)
+	require.NoError(t, err)
+
+	app := f.newApp(templates...)
+	app.dryRun, app.diff = cfg.DryRun, cfg.Diff
+
+	app.RunOnce()
+	require.False(t, app.runFailed)
+	require.Equal(t, []string{"pods.tmpl"}, app.diffTemplates)
+	require.Equal(t, []string{"true"}, app.diffCommands)
+
+	actual, err := ioutil.ReadFile(output)
+	require.NoError(t, err)
+	require.Equal(t, "pods: 0\n", string(actual))
+
+	buf := new(bytes.Buffer)
+	require.True(t, app.printDiffSummary(buf))
+	require.Equal(t, "1 template output(s) would change: pods.tmpl\ncommand would run: \"true\"\n", buf.String())
+
+	// No changes if output is up to date
+	require.NoError(t, ioutil.WriteFile(output, []byte("pods: 1\n"), 0644))
+	templates, err = newTemplatesFromConfig(cfg, f.dm)
+	require.NoError(t, err)
+	app.templates = templates
+
+	app.RunOnce()
+	buf.Reset()
+	require.False(t, app.printDiffSummary(buf))
+	require.Empty(t, app.diffCommands)
+}
+
+func TestAppCommandRollback(t *testing.T) {
+	f := newTestFixture(t, fake.NewSimpleClientset(testutil.NewPod("pod1", "host1")), false)
+	defer f.Close()
+
+	output := filepath.Join(f.dir, "pods.txt")
+	require.NoError(t, ioutil.WriteFile(output, []byte("pods: 0\n"), 0644))
+
+	tmpl := f.newTemplate("pods: {{len (pods)}}\n", &TemplateDescriptor{Output: "pods.txt", Command: "false"})
+	app := f.newApp(tmpl)
+
+	require.Error(t, app.RunOnce())
+
+	actual, err := ioutil.ReadFile(output)
+	require.NoError(t, err)
+	require.Equal(t, "pods: 0\n", string(actual))
+	require.Equal(t, "pods: 1\n", tmpl.lastOutput)
+
+	// Rolled back output is not written again until rendered template changes
+	require.NoError(t, app.RunOnce())
+	actual, err = ioutil.ReadFile(output)
+	require.NoError(t, err)
+	require.Equal(t, "pods: 0\n", string(actual))
+}
+
+func TestAppCommandsDeduplication(t *testing.T) {
+	f := newTestFixture(t, fake.NewSimpleClientset(), false)
+	defer f.Close()
+
+	var templates []*Template
+	for _, d := range []*TemplateDescriptor{
+		{Command: "true"},
+		{Exec: []string{"true"}},
+		{Exec: []string{"echo", "a b"}},
+		{Command: "echo a b"},
+		{ReloadSignal: &SignalAction{PidFile: "/run/nginx.pid", Signal: syscall.SIGHUP}},
+		{ReloadSignal: &SignalAction{PidFile: "/run/nginx.pid", Signal: syscall.SIGHUP}},
+		{ReloadSignal: &SignalAction{PidFile: "/run/nginx.pid", Signal: syscall.SIGQUIT}},
+	} {
+		templates = append(templates, f.newTemplate("test", d))
+	}
+
+	app := f.newApp(templates...)
+	app.dryRun, app.diff = true, true
+
+	app.RunOnce()
+	require.Equal(t, []string{"true", `echo "a b"`, "echo a b",
+		"kill -SIGHUP $(cat /run/nginx.pid)", "kill -SIGQUIT $(cat /run/nginx.pid)"}, app.diffCommands)
+}
+
+func TestAppCommandEnv(t *testing.T) {
+	f := newTestFixture(t, fake.NewSimpleClientset(), false)
+	defer f.Close()
+
+	envFile, checkEnvFile := filepath.Join(f.dir, "env"), filepath.Join(f.dir, "check env")
+	printEnv := fmt.Sprintf(`printf '%%s\n' "FOO=$FOO" "$%s" "$%s" "$%s"`, EnvChangedOutputs, EnvDryRun, EnvTemplates)
+	command := printEnv + " > " + ShellQuote(envFile)
+
+	templates := []*Template{
+		f.newTemplate("test", &TemplateDescriptor{Command: command, Output: "0 out.txt"}),
+		f.newTemplate("test", &TemplateDescriptor{Command: command, Output: "1 out.txt",
+			Env: map[string]string{"FOO": "bar"}, Check: printEnv + " > " + ShellQuote(checkEnvFile)}),
+	}
+	app := f.newApp(templates...)
+
+	app.RunOnce()
+
+	env, err := ioutil.ReadFile(envFile)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("FOO=bar\n%s\n%s\nfalse\n%s\n%s\n",
+		templates[0].desc.Output, templates[1].desc.Output,
+		templates[0].desc.Path, templates[1].desc.Path), string(env))
+
+	// Check command gets the same variables for its template
+	env, err = ioutil.ReadFile(checkEnvFile)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("FOO=bar\n%s\nfalse\n%s\n",
+		templates[1].desc.Output, templates[1].desc.Path), string(env))
+}
+
+// Test fixture: temp dir for templates and their outputs, and client using given Kubernetes client
+type testFixture struct {
+	t      *testing.T
+	dir    string
+	stopCh chan struct{}
+	client *Client
+	dm     *DependencyManager
+	// Number of templates created
+	count int
+}
+
+// Create test fixture, which should be closed after use
+func newTestFixture(t *testing.T, kubeClient kubernetes.Interface, useInformers bool) *testFixture {
+	dir, err := ioutil.TempDir("", "kube-template")
+	require.NoError(t, err)
+
+	stopCh := make(chan struct{})
+	tc, err := newClient(kubeClient, nil, stopCh, useInformers)
+	require.NoError(t, err)
+
+	return &testFixture{
+		t:      t,
+		dir:    dir,
+		stopCh: stopCh,
+		client: tc,
+		dm:     newDependencyManager(tc),
+	}
+}
+
+// Stop client, unless stopped by app, and remove fixture dir
+func (f *testFixture) Close() {
+	select {
+	case <-f.stopCh:
+	default:
+		close(f.stopCh)
+	}
+	_ = os.RemoveAll(f.dir)
+}
+
+// Create template with given content and descriptor. Relative template and output paths are
+// resolved in fixture dir, template path is '<n>.tmpl' and output one is '<n>.txt' if not set.
+// Content is ignored for templates read from ConfigMap or HTTP(S) URL.
+func (f *testFixture) newTemplate(content string, d *TemplateDescriptor) *Template {
+	if d.Path == "" {
+		d.Path = fmt.Sprintf("%d.tmpl", f.count)
+	}
+	if d.Output == "" {
+		d.Output = fmt.Sprintf("%d.txt", f.count)
+	}
+	f.count++
+	if !filepath.IsAbs(d.Output) && !isKubeOutput(d.Output) {
+		d.Output = filepath.Join(f.dir, d.Output)
+	}
+	if !strings.Contains(d.Path, "://") {
+		if !filepath.IsAbs(d.Path) {
+			d.Path = filepath.Join(f.dir, d.Path)
+		}
+		require.NoError(f.t, ioutil.WriteFile(d.Path, []byte(content), 0644))
+	}
+	tmpl, err := newTemplate(&Config{LeftDelimiter: "{{", RightDelimiter: "}}"}, f.dm, d)
+	require.NoError(f.t, err)
+	return tmpl
+}
+
+// Create app processing given templates
+func (f *testFixture) newApp(templates ...*Template) *App {
+	return &App{
+		stopCh:    f.stopCh,
+		doneCh:    make(chan struct{}),
+		dm:        f.dm,
+		templates: templates,
+		updateCh:  f.client.Updates(),
+	}
+}
+
+// Create app with single template 'in.tmpl' updating 'out.txt' output from 'old' to 'new'
+func newTestCommandApp(f *testFixture, d *TemplateDescriptor) *App {
+	require.NoError(f.t, ioutil.WriteFile(filepath.Join(f.dir, "out.txt"), []byte("old"), 0644))
+	d.Path, d.Output = "in.tmpl", "out.txt"
+	return f.newApp(f.newTemplate("new", d))
+}
+
+func TestAppCommandRetries(t *testing.T) {
+	f := newTestFixture(t, fake.NewSimpleClientset(), false)
+	defer f.Close()
+
+	// Command fails on first attempt only
+	marker := filepath.Join(f.dir, "marker")
+	app := newTestCommandApp(f, &TemplateDescriptor{
+		Command: fmt.Sprintf("test -f %s || { touch %s; exit 1; }", marker, marker),
+		Retries: 1,
+		Backoff: 10 * time.Millisecond,
+	})
+	app.runOnce = true
+
+	app.RunOnce()
+	require.Empty(t, app.pending)
+
+	actual, err := ioutil.ReadFile(filepath.Join(f.dir, "out.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "new", string(actual))
+}
+
+func TestAppCommandFailureIgnore(t *testing.T) {
+	f := newTestFixture(t, fake.NewSimpleClientset(), false)
+	defer f.Close()
+
+	app := newTestCommandApp(f, &TemplateDescriptor{
+		Command:   "false",
+		OnFailure: OnFailureIgnore,
+	})
+	app.runOnce = true
+
+	app.RunOnce()
+	require.Empty(t, app.pending)
+
+	actual, err := ioutil.ReadFile(filepath.Join(f.dir, "out.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "new", string(actual))
+}
+
+func TestAppCommandFailureRetry(t *testing.T) {
+	f := newTestFixture(t, fake.NewSimpleClientset(), false)
+	defer f.Close()
+
+	app := newTestCommandApp(f, &TemplateDescriptor{
+		Command:   "false",
+		Backoff:   time.Minute,
+		OnFailure: OnFailureRetry,
+	})
+
+	start := time.Now()
+	app.Run()
+	require.Len(t, app.pending, 1)
+	sc := app.pending[0]
+	require.Equal(t, 1, sc.attempts)
+	require.True(t, !sc.due.Before(start.Add(time.Minute)))
+
+	// Not due yet
+	app.retryPending(start)
+	require.Equal(t, 1, sc.attempts)
+
+	app.retryPending(app.nextRetry())
+	require.Len(t, app.pending, 1)
+	require.Equal(t, 2, sc.attempts)
+	require.True(t, !sc.due.Before(start.Add(2*time.Minute)))
+
+	// Pending command is rescheduled on template update
+	app.templates[0].lastOutput = ""
+	app.Run()
+	require.Len(t, app.pending, 1)
+	require.Equal(t, 1, app.pending[0].attempts)
+
+	actual, err := ioutil.ReadFile(filepath.Join(f.dir, "out.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "new", string(actual))
+}
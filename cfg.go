@@ -16,20 +16,40 @@ package main
 
 import (
 	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/golang/glog"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/util/uuid"
 )
 
 const (
-	CfgFile           = "kube-template"
-	CfgMaster         = FlagMaster
-	CfgPollTime       = FlagPollTime
-	CfgPollPeriod     = FlagPollPeriod
-	CfgCommandTimeout = FlagCommandTimeout
+	CfgFile               = "kube-template"
+	CfgMaster             = FlagMaster
+	CfgPollTime           = FlagPollTime
+	CfgPollPeriod         = FlagPollPeriod
+	CfgSourcePollPeriod   = FlagSourcePollPeriod
+	CfgCommandTimeout     = FlagCommandTimeout
+	CfgCommandKillTimeout = FlagCommandKillTimeout
+	CfgWatch              = FlagWatch
+	CfgWait               = FlagWait
+	CfgStrict             = FlagStrict
+	CfgNamespace          = FlagNamespace
+	CfgFixtures           = FlagFixtures
+
+	CfgLeaderElect               = FlagLeaderElect
+	CfgLeaderElectLeaseName      = FlagLeaderElectLeaseName
+	CfgLeaderElectLeaseNamespace = FlagLeaderElectLeaseNamespace
+	CfgLeaderElectLeaseDuration  = FlagLeaderElectLeaseDuration
+	CfgLeaderElectRenewDeadline  = FlagLeaderElectRenewDeadline
+	CfgLeaderElectRetryPeriod    = FlagLeaderElectRetryPeriod
 )
 
 var cfgFile string
@@ -39,16 +59,36 @@ type Config struct {
 	DryRun bool
 	// Run template processing once and exit
 	RunOnce bool
+	// Show diff between template outputs on disk and rendered templates
+	Diff bool
 	// Guess Kubernetes API settings from POD environment
 	GuessKubeAPISettings bool
 	// Kubernetes config file
 	KubeConfig string
 	// Kubernetes API server address
 	Master string
+	// Kubernetes objects manifests file or directory for offline rendering
+	Fixtures string
+	// Watch Kubernetes API server for objects updates
+	Watch bool
 	// Kubernetes API server poll period
 	PollPeriod time.Duration
+	// HTTP(S) template sources poll period
+	SourcePollPeriod time.Duration
+	// Default quiescence timers settings
+	Wait WaitConfig
 	// Command execution timeout
 	CommandTimeout time.Duration
+	// Time to wait for command to exit after termination on timeout before killing it
+	CommandKillTimeout time.Duration
+
+	// Fail if single object requested by name is not found
+	Strict bool
+	// Default namespace to query objects from
+	Namespace string
+
+	// Leader election settings
+	LeaderElection LeaderElectionConfig
 
 	// Template delimiters
 	LeftDelimiter  string
@@ -66,10 +106,121 @@ type TemplateDescriptor struct {
 	Path string
 	// Template output path
 	Output string
-	// Optional command to execute after template output updating
+	// Template output file permissions, default ones if zero
+	Perms os.FileMode
+	// Template output file owner user name or id, not changed if empty
+	User string
+	// Template output file owner group name or id, not changed if empty
+	Group string
+	// Optional command to check new template output before updating, as a template
+	Check string
+	// Optional command to execute using system shell after template output updating
 	Command string
+	// Optional command arguments to execute directly after template output updating
+	Exec []string
+	// Optional signal to send to process from pid file after template output updating
+	ReloadSignal *SignalAction
+	// Optional HTTP request to send after template output updating
+	Webhook *WebhookAction
+	// Additional environment variables for commands
+	Env map[string]string
 	// Command timeout
 	CommandTimeout time.Duration
+	// Time to wait for command to exit after termination on timeout before killing it
+	CommandKillTimeout time.Duration
+	// Number of failed command retries
+	Retries int
+	// Delay before first failed command retry, doubled for each next one
+	Backoff time.Duration
+	// Action when failed command retries are exhausted
+	OnFailure string
+	// Quiescence timers settings
+	Wait WaitConfig
+}
+
+// Actions when failed command retries are exhausted
+const (
+	// Keep retrying command
+	OnFailureRetry = "retry"
+	// Roll back outputs of templates updated before command execution
+	OnFailureRollback = "rollback"
+	// Keep outputs of templates updated
+	OnFailureIgnore = "ignore"
+)
+
+// Delay before first failed command retry, if not configured
+const DefaultCommandBackoff = time.Second
+
+// Returns command to execute after template output updating
+func (d *TemplateDescriptor) command() Command {
+	return Command{Line: d.Command, Args: d.Exec}
+}
+
+// Returns actions to execute after template output updating
+func (d *TemplateDescriptor) actions() []Action {
+	var actions []Action
+	if cmd := d.command(); !cmd.IsEmpty() {
+		actions = append(actions, cmd)
+	}
+	if d.ReloadSignal != nil {
+		actions = append(actions, d.ReloadSignal)
+	}
+	if d.Webhook != nil {
+		actions = append(actions, d.Webhook)
+	}
+	return actions
+}
+
+// Signal to send to process from pid file, if not configured
+const DefaultReloadSignal = "SIGHUP"
+
+// Parse reload signal action in the format '{pidfile: path, signal: name}'
+func parseReloadSignal(v interface{}) (*SignalAction, error) {
+	m, ok := v.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid reload signal value: %v", v)
+	}
+	a := &SignalAction{}
+	sig := DefaultReloadSignal
+	for ik, iv := range m {
+		switch k := fmt.Sprint(ik); k {
+		case "pidfile":
+			a.PidFile = fmt.Sprint(iv)
+		case "signal":
+			sig = fmt.Sprint(iv)
+		default:
+			return nil, fmt.Errorf("unknown reload signal option: %s", k)
+		}
+	}
+	if a.PidFile == "" {
+		return nil, errors.New("reload signal pid file is not set")
+	}
+	var err error
+	if a.Signal, err = ParseSignal(sig); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+type LeaderElectionConfig struct {
+	// Only leader writes template outputs and executes commands
+	Enabled bool
+	// Lease name and namespace
+	LeaseName      string
+	LeaseNamespace string
+	// Leader election identity
+	Identity string
+	// Lease duration, leadership renewal deadline and acquisition retry period
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+}
+
+type WaitConfig struct {
+	// Minimum time to wait for objects updates to settle before rendering
+	Min time.Duration
+	// Maximum time to wait before rendering while objects updates keep coming
+	Max time.Duration
 }
 
 func readConfig(cmd *cobra.Command) error {
@@ -90,10 +241,62 @@ func readConfig(cmd *cobra.Command) error {
 		return err
 	}
 
+	if err := viper.BindPFlag(CfgSourcePollPeriod, cmd.Flags().Lookup(FlagSourcePollPeriod)); err != nil {
+		return err
+	}
+
 	if err := viper.BindPFlag(CfgCommandTimeout, cmd.Flags().Lookup(FlagCommandTimeout)); err != nil {
 		return err
 	}
 
+	if err := viper.BindPFlag(CfgCommandKillTimeout, cmd.Flags().Lookup(FlagCommandKillTimeout)); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag(CfgWatch, cmd.Flags().Lookup(FlagWatch)); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag(CfgWait, cmd.Flags().Lookup(FlagWait)); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag(CfgStrict, cmd.Flags().Lookup(FlagStrict)); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag(CfgNamespace, cmd.Flags().Lookup(FlagNamespace)); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag(CfgFixtures, cmd.Flags().Lookup(FlagFixtures)); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag(CfgLeaderElect, cmd.Flags().Lookup(FlagLeaderElect)); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag(CfgLeaderElectLeaseName, cmd.Flags().Lookup(FlagLeaderElectLeaseName)); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag(CfgLeaderElectLeaseNamespace, cmd.Flags().Lookup(FlagLeaderElectLeaseNamespace)); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag(CfgLeaderElectLeaseDuration, cmd.Flags().Lookup(FlagLeaderElectLeaseDuration)); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag(CfgLeaderElectRenewDeadline, cmd.Flags().Lookup(FlagLeaderElectRenewDeadline)); err != nil {
+		return err
+	}
+
+	if err := viper.BindPFlag(CfgLeaderElectRetryPeriod, cmd.Flags().Lookup(FlagLeaderElectRetryPeriod)); err != nil {
+		return err
+	}
+
 	err := viper.ReadInConfig()
 
 	if err == nil {
@@ -109,14 +312,43 @@ func readConfig(cmd *cobra.Command) error {
 	return nil
 }
 
-// Parses a string in format 'templatePath:outputPath[:command]' into a TemplateDescriptor struct
+// URL scheme prefix of template descriptor field, e.g. 'configmap://'
+var schemePrefixRe = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*://`)
+
+// Split template descriptor string into given maximum number of colon-separated fields,
+// keeping colons of URL scheme and authority (e.g. 'https://host:8443/path') in fields
+func splitDescriptor(s string, n int) []string {
+	var parts []string
+	for len(parts) < n-1 {
+		start := 0
+		if prefix := schemePrefixRe.FindString(s); prefix != "" {
+			// Skip URL scheme and authority, which may contain port
+			start = len(prefix)
+			if i := strings.Index(s[start:], "/"); i >= 0 {
+				start += i
+			} else {
+				start = len(s)
+			}
+		}
+		i := strings.Index(s[start:], ":")
+		if i < 0 {
+			break
+		}
+		parts = append(parts, s[:start+i])
+		s = s[start+i+1:]
+	}
+	return append(parts, s)
+}
+
+// Parses a string in format 'templatePath:outputPath[?options][:command]' into a TemplateDescriptor struct,
+// where options are template output file options in URL query format, e.g. 'perms=0600&user=nginx'
 func parseTemplateDescriptor(s string) (*TemplateDescriptor, error) {
 	if len(strings.TrimSpace(s)) == 0 {
 		return nil, errors.New("empty template descriptor string")
 	}
 
 	var path, output, command string
-	parts := strings.SplitN(s, ":", 3)
+	parts := splitDescriptor(s, 3)
 
 	switch len(parts) {
 	case 2:
@@ -124,14 +356,227 @@ func parseTemplateDescriptor(s string) (*TemplateDescriptor, error) {
 	case 3:
 		path, output, command = parts[0], parts[1], parts[2]
 	default:
-		return nil, errors.New("invalid template descriptor, should be 'templatePath:outputPath[:command]'")
+		return nil, errors.New("invalid template descriptor, should be 'templatePath:outputPath[?options][:command]'")
 	}
 
-	return &TemplateDescriptor{
+	d := &TemplateDescriptor{
 		Path:    path,
-		Output:  output,
 		Command: command,
-	}, nil
+	}
+
+	// Parse output file options, if any
+	if i := strings.Index(output, "?"); i >= 0 {
+		options, err := url.ParseQuery(output[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid template output options: %v", err)
+		}
+		for key, values := range options {
+			if err := d.setOutputOption(key, values[len(values)-1]); err != nil {
+				return nil, err
+			}
+		}
+		output = output[:i]
+	}
+	d.Output = output
+
+	return d, nil
+}
+
+// Template output file options
+const (
+	OutputOptionPerms = "perms"
+	OutputOptionUser  = "user"
+	OutputOptionUid   = "uid"
+	OutputOptionGroup = "group"
+	OutputOptionGid   = "gid"
+)
+
+var outputOptions = []string{OutputOptionPerms, OutputOptionUser, OutputOptionUid, OutputOptionGroup, OutputOptionGid}
+
+// Set template output file option with given key to given value
+func (d *TemplateDescriptor) setOutputOption(key string, value interface{}) error {
+	switch key {
+	case OutputOptionPerms:
+		perms, err := parsePerms(value)
+		if err != nil {
+			return err
+		}
+		d.Perms = perms
+	case OutputOptionUser, OutputOptionUid:
+		d.User = fmt.Sprint(value)
+	case OutputOptionGroup, OutputOptionGid:
+		d.Group = fmt.Sprint(value)
+	default:
+		return fmt.Errorf("unknown template output option: %s", key)
+	}
+	return nil
+}
+
+// Parses file permissions given either as an octal string or as a number
+func parsePerms(v interface{}) (os.FileMode, error) {
+	var perms uint64
+	switch p := v.(type) {
+	case int:
+		perms = uint64(p)
+	case string:
+		var err error
+		if perms, err = strconv.ParseUint(p, 8, 32); err != nil {
+			return 0, fmt.Errorf("invalid file permissions value: %v", v)
+		}
+	default:
+		return 0, fmt.Errorf("invalid file permissions value: %v", v)
+	}
+	if perms == 0 || perms > uint64(os.ModePerm) {
+		return 0, fmt.Errorf("invalid file permissions value: %v", v)
+	}
+	return os.FileMode(perms), nil
+}
+
+// Parses a string in format 'min[:max]' into a WaitConfig struct
+func parseWaitConfig(s string) (WaitConfig, error) {
+	var w WaitConfig
+	if len(strings.TrimSpace(s)) == 0 {
+		return w, nil
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) > 2 {
+		return w, errors.New("invalid wait value, should be 'min[:max]'")
+	}
+
+	var err error
+	if w.Min, err = time.ParseDuration(strings.TrimSpace(parts[0])); err != nil {
+		return w, err
+	}
+	if len(parts) == 2 {
+		if w.Max, err = time.ParseDuration(strings.TrimSpace(parts[1])); err != nil {
+			return w, err
+		}
+	} else {
+		// Maximum wait time is not set, use 4x of minimum one as consul-template does
+		w.Max = 4 * w.Min
+	}
+
+	return w, w.validate()
+}
+
+// Parses wait value from config, either in format 'min[:max]' or as a map with 'min' and 'max' keys
+func parseWait(v interface{}) (WaitConfig, error) {
+	var m map[string]interface{}
+	switch w := v.(type) {
+	case string:
+		return parseWaitConfig(w)
+	case map[string]interface{}:
+		m = w
+	case map[interface{}]interface{}:
+		m = make(map[string]interface{})
+		for k, v := range w {
+			m[fmt.Sprint(k)] = v
+		}
+	default:
+		return WaitConfig{}, fmt.Errorf("invalid wait value: %v", v)
+	}
+
+	var w WaitConfig
+	var err error
+	if iMin, minPresent := m["min"]; minPresent {
+		if w.Min, err = parseDuration(iMin); err != nil {
+			return w, err
+		}
+	}
+	if iMax, maxPresent := m["max"]; maxPresent {
+		if w.Max, err = parseDuration(iMax); err != nil {
+			return w, err
+		}
+	} else {
+		w.Max = 4 * w.Min
+	}
+
+	return w, w.validate()
+}
+
+// Parses environment variables map from config
+func parseEnv(v interface{}) (map[string]string, error) {
+	env := make(map[string]string)
+	switch m := v.(type) {
+	case map[string]interface{}:
+		for k, v := range m {
+			env[k] = fmt.Sprint(v)
+		}
+	case map[interface{}]interface{}:
+		for k, v := range m {
+			env[fmt.Sprint(k)] = fmt.Sprint(v)
+		}
+	default:
+		return nil, fmt.Errorf("invalid env value: %v", v)
+	}
+	for k := range env {
+		if k == "" || strings.ContainsAny(k, "=\x00") {
+			return nil, fmt.Errorf("invalid env variable name: %q", k)
+		}
+	}
+	return env, nil
+}
+
+// Parses duration value given either as a number of seconds or as a duration string
+func parseDuration(v interface{}) (time.Duration, error) {
+	switch d := v.(type) {
+	case int:
+		return time.Duration(d) * time.Second, nil
+	case float64:
+		return time.Duration(d * float64(time.Second)), nil
+	case string:
+		return time.ParseDuration(d)
+	}
+	return 0, fmt.Errorf("invalid duration value: %v", v)
+}
+
+func newLeaderElectionConfig(config *Config) (LeaderElectionConfig, error) {
+	le := LeaderElectionConfig{
+		Enabled:        viper.GetBool(CfgLeaderElect),
+		LeaseName:      viper.GetString(CfgLeaderElectLeaseName),
+		LeaseNamespace: viper.GetString(CfgLeaderElectLeaseNamespace),
+		LeaseDuration:  viper.GetDuration(CfgLeaderElectLeaseDuration),
+		RenewDeadline:  viper.GetDuration(CfgLeaderElectRenewDeadline),
+		RetryPeriod:    viper.GetDuration(CfgLeaderElectRetryPeriod),
+	}
+	if !le.Enabled {
+		return le, nil
+	}
+	if config.RunOnce {
+		return le, errors.New("leader election can't be used in run once mode")
+	}
+	if config.Fixtures != "" {
+		return le, errors.New("leader election can't be used with fixtures")
+	}
+	if le.LeaseName == "" {
+		return le, errors.New("leader election lease name is not set")
+	}
+	if le.LeaseNamespace == "" {
+		le.LeaseNamespace = config.Namespace
+	}
+	if le.LeaseDuration <= le.RenewDeadline || le.RenewDeadline <= le.RetryPeriod || le.RetryPeriod <= 0 {
+		return le, fmt.Errorf("invalid leader election timings: lease duration (%v) should be greater than "+
+			"renew deadline (%v), which should be greater than retry period (%v)",
+			le.LeaseDuration, le.RenewDeadline, le.RetryPeriod)
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return le, err
+	}
+	le.Identity = hostname + "_" + string(uuid.NewUUID())
+	glog.V(2).Infof("leader election enabled using lease %s/%s, identity: %s", le.LeaseNamespace, le.LeaseName, le.Identity)
+	return le, nil
+}
+
+func (w WaitConfig) validate() error {
+	if w.Min < 0 || w.Max < 0 {
+		return errors.New("wait time can't be negative")
+	}
+	if w.Max < w.Min {
+		return fmt.Errorf("maximum wait time (%v) is less than minimum one (%v)", w.Max, w.Min)
+	}
+	return nil
 }
 
 func newConfig(cmd *cobra.Command) (*Config, error) {
@@ -148,6 +593,14 @@ func newConfig(cmd *cobra.Command) (*Config, error) {
 		return nil, err
 	}
 	config.RunOnce = runOnce
+	diff, err := cmd.Flags().GetBool(FlagDiff)
+	if err != nil {
+		return nil, err
+	}
+	if diff {
+		// Diff mode implies dry run once
+		config.Diff, config.DryRun, config.RunOnce = true, true, true
+	}
 	guessKubeAPISettings, err := cmd.Flags().GetBool(FlagGuessKubeApiSettings)
 	if err != nil {
 		return nil, err
@@ -178,6 +631,13 @@ func newConfig(cmd *cobra.Command) (*Config, error) {
 	}
 	// Get command line / config options
 	config.Master = viper.GetString(CfgMaster)
+	config.Fixtures = viper.GetString(CfgFixtures)
+	config.Watch = viper.GetBool(CfgWatch)
+	config.Strict = viper.GetBool(CfgStrict)
+	config.Namespace = viper.GetString(CfgNamespace)
+	if config.Namespace == "" || config.Namespace == AllNamespaces || strings.Contains(config.Namespace, ",") {
+		return nil, fmt.Errorf("invalid default namespace: %q", config.Namespace)
+	}
 	if viper.IsSet(CfgPollTime) {
 		config.PollPeriod = viper.GetDuration(CfgPollTime)
 		glog.Warningf("'%s' parameter is deprecated, use '%s' instead", CfgPollTime, CfgPollPeriod)
@@ -185,8 +645,19 @@ func newConfig(cmd *cobra.Command) (*Config, error) {
 		config.PollPeriod = viper.GetDuration(CfgPollPeriod)
 	}
 	glog.V(2).Infof("poll period set to %v", config.PollPeriod)
+	config.SourcePollPeriod = viper.GetDuration(CfgSourcePollPeriod)
+	glog.V(2).Infof("template sources poll period set to %v", config.SourcePollPeriod)
 	config.CommandTimeout = viper.GetDuration(FlagCommandTimeout)
 	glog.V(2).Infof("command timeout set to %v", config.CommandTimeout)
+	config.CommandKillTimeout = viper.GetDuration(CfgCommandKillTimeout)
+	glog.V(2).Infof("command kill timeout set to %v", config.CommandKillTimeout)
+	if config.Wait, err = parseWait(viper.Get(CfgWait)); err != nil {
+		return nil, err
+	}
+	glog.V(2).Infof("wait set to %v:%v", config.Wait.Min, config.Wait.Max)
+	if config.LeaderElection, err = newLeaderElectionConfig(config); err != nil {
+		return nil, err
+	}
 	// Add template descriptors specified by command line
 	cmdTemplates, err := cmd.Flags().GetStringSlice(FlagTemplate)
 	if err != nil {
@@ -199,6 +670,8 @@ func newConfig(cmd *cobra.Command) (*Config, error) {
 		if err != nil {
 			glog.Errorf("can't parse '%s': %v", template, err)
 		} else {
+			d.Wait = config.Wait
+			d.CommandKillTimeout = config.CommandKillTimeout
 			glog.V(2).Infof("adding template from command line: %s", d.Path)
 			config.appendTemplateDescriptor(d)
 		}
@@ -215,27 +688,132 @@ func newConfig(cmd *cobra.Command) (*Config, error) {
 				continue
 			}
 			path, output := iPath.(string), iOutput.(string)
-			// Command and its timeout are optional
-			var cmd string
+			// Command, check command and their timeout are optional
+			var cmd, check string
 			if iCmd, cmdPresent := cfgTemplate["command"]; cmdPresent {
 				cmd = iCmd.(string)
 			}
+			if iCheck, checkPresent := cfgTemplate["check"]; checkPresent {
+				check = iCheck.(string)
+			}
+			var execArgs []string
+			if iExec, execPresent := cfgTemplate["exec"]; execPresent {
+				args, ok := iExec.([]interface{})
+				if !ok || len(args) == 0 {
+					glog.Warningf("skipped template descriptor with invalid exec value: %s: %v", path, iExec)
+					continue
+				}
+				for _, arg := range args {
+					execArgs = append(execArgs, fmt.Sprint(arg))
+				}
+				if cmd != "" {
+					glog.Warningf("skipped template descriptor with both command and exec set: %s", path)
+					continue
+				}
+			}
+			var reloadSignal *SignalAction
+			if iReloadSignal, reloadSignalPresent := cfgTemplate["reload-signal"]; reloadSignalPresent {
+				var err error
+				if reloadSignal, err = parseReloadSignal(iReloadSignal); err != nil {
+					glog.Warningf("skipped template descriptor with invalid reload-signal value: %s: %v", path, err)
+					continue
+				}
+			}
+			var webhook *WebhookAction
+			if iWebhook, webhookPresent := cfgTemplate["webhook"]; webhookPresent {
+				var err error
+				if webhook, err = parseWebhook(iWebhook); err != nil {
+					glog.Warningf("skipped template descriptor with invalid webhook value: %s: %v", path, err)
+					continue
+				}
+			}
+			var env map[string]string
+			if iEnv, envPresent := cfgTemplate["env"]; envPresent {
+				var err error
+				if env, err = parseEnv(iEnv); err != nil {
+					glog.Warningf("skipped template descriptor with invalid env value: %s: %v", path, err)
+					continue
+				}
+			}
 			cmdTimeout := config.CommandTimeout
 			if iCmdTimeout, cmdTimeoutPresent := cfgTemplate[FlagCommandTimeout]; cmdTimeoutPresent {
-				if i, ok := iCmdTimeout.(int); ok {
-					cmdTimeout = time.Duration(i) * time.Second
-				} else if d, err := time.ParseDuration(iCmdTimeout.(string)); err == nil {
+				if d, err := parseDuration(iCmdTimeout); err == nil {
 					cmdTimeout = d
 				} else {
 					glog.Warningf("ignoring invalid command timeout value: %v", iCmdTimeout)
 				}
 			}
+			cmdKillTimeout := config.CommandKillTimeout
+			if iCmdKillTimeout, cmdKillTimeoutPresent := cfgTemplate[CfgCommandKillTimeout]; cmdKillTimeoutPresent {
+				if d, err := parseDuration(iCmdKillTimeout); err == nil {
+					cmdKillTimeout = d
+				} else {
+					glog.Warningf("ignoring invalid command kill timeout value: %v", iCmdKillTimeout)
+				}
+			}
+			// Command failure handling settings are optional
+			retries, backoff, onFailure := 0, DefaultCommandBackoff, OnFailureRollback
+			if iRetries, retriesPresent := cfgTemplate["retries"]; retriesPresent {
+				if r, ok := iRetries.(int); ok && r >= 0 {
+					retries = r
+				} else {
+					glog.Warningf("ignoring invalid retries value: %v", iRetries)
+				}
+			}
+			if iBackoff, backoffPresent := cfgTemplate["backoff"]; backoffPresent {
+				if d, err := parseDuration(iBackoff); err == nil && d > 0 {
+					backoff = d
+				} else {
+					glog.Warningf("ignoring invalid backoff value: %v", iBackoff)
+				}
+			}
+			if iOnFailure, onFailurePresent := cfgTemplate["on-failure"]; onFailurePresent {
+				switch f := fmt.Sprint(iOnFailure); f {
+				case OnFailureRetry, OnFailureRollback, OnFailureIgnore:
+					onFailure = f
+				default:
+					glog.Warningf("skipped template descriptor with invalid on-failure value: %s: %v", path, f)
+					continue
+				}
+			}
+			// Wait is optional, global one is used if not set
+			wait := config.Wait
+			if iWait, waitPresent := cfgTemplate[CfgWait]; waitPresent {
+				if w, err := parseWait(iWait); err == nil {
+					wait = w
+				} else {
+					glog.Warningf("ignoring invalid wait value: %v: %v", iWait, err)
+				}
+			}
 			// Add template descriptor
 			d := &TemplateDescriptor{
-				Path:           path,
-				Output:         output,
-				Command:        cmd,
-				CommandTimeout: cmdTimeout,
+				Path:               path,
+				Output:             output,
+				Check:              check,
+				Command:            cmd,
+				Exec:               execArgs,
+				ReloadSignal:       reloadSignal,
+				Webhook:            webhook,
+				Env:                env,
+				CommandTimeout:     cmdTimeout,
+				CommandKillTimeout: cmdKillTimeout,
+				Retries:            retries,
+				Backoff:            backoff,
+				OnFailure:          onFailure,
+				Wait:               wait,
+			}
+			// Output file options are optional
+			var err error
+			for _, key := range outputOptions {
+				if value, present := cfgTemplate[key]; present {
+					if err = d.setOutputOption(key, value); err != nil {
+						break
+					}
+				}
+			}
+			if err != nil {
+				glog.Warningf("skipped template descriptor with invalid output options: %s: %v", path, err)
+				continue
 			}
 			glog.V(2).Infof("adding template from config file: %s", d.Path)
 			config.appendTemplateDescriptor(d)
@@ -254,6 +832,10 @@ func (cfg *Config) appendTemplateDescriptor(d *TemplateDescriptor) {
 	}
 }
 
+func (cfg *Config) WatchEnabled() bool {
+	return !cfg.RunOnce && cfg.Watch
+}
+
 func (cfg *Config) PollingEnabled() bool {
-	return !cfg.RunOnce && cfg.PollPeriod.Nanoseconds() > 0
+	return !cfg.RunOnce && !cfg.Watch && cfg.PollPeriod.Nanoseconds() > 0
 }
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"github.com/stretchr/testify/require"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestParseWait(t *testing.T) {
+	w, err := parseWait("")
+	require.NoError(t, err)
+	require.Equal(t, WaitConfig{}, w)
+
+	w, err = parseWait("2s:10s")
+	require.NoError(t, err)
+	require.Equal(t, WaitConfig{Min: 2 * time.Second, Max: 10 * time.Second}, w)
+
+	w, err = parseWait("1s")
+	require.NoError(t, err)
+	require.Equal(t, WaitConfig{Min: time.Second, Max: 4 * time.Second}, w)
+
+	w, err = parseWait(map[interface{}]interface{}{"min": "500ms", "max": 3})
+	require.NoError(t, err)
+	require.Equal(t, WaitConfig{Min: 500 * time.Millisecond, Max: 3 * time.Second}, w)
+
+	_, err = parseWait("10s:2s")
+	require.Error(t, err)
+
+	_, err = parseWait("1s:2s:3s")
+	require.Error(t, err)
+}
+
+func TestParseTemplateDescriptor(t *testing.T) {
+	d, err := parseTemplateDescriptor("in.tmpl:out.txt:echo a:b")
+	require.NoError(t, err)
+	require.Equal(t, &TemplateDescriptor{Path: "in.tmpl", Output: "out.txt", Command: "echo a:b"}, d)
+
+	d, err = parseTemplateDescriptor("in.tmpl:/etc/ssl/tls.key?perms=0600&user=nginx&gid=101:nginx -s reload")
+	require.NoError(t, err)
+	require.Equal(t, &TemplateDescriptor{Path: "in.tmpl", Output: "/etc/ssl/tls.key", Command: "nginx -s reload",
+		Perms: 0600, User: "nginx", Group: "101"}, d)
+
+	d, err = parseTemplateDescriptor("in.tmpl:configmap://default/nginx/nginx.conf:echo a:b")
+	require.NoError(t, err)
+	require.Equal(t, &TemplateDescriptor{Path: "in.tmpl", Output: "configmap://default/nginx/nginx.conf", Command: "echo a:b"}, d)
+
+	d, err = parseTemplateDescriptor("https://example.com:8443/in.tmpl:secret://default/nginx/tls.key")
+	require.NoError(t, err)
+	require.Equal(t, &TemplateDescriptor{Path: "https://example.com:8443/in.tmpl", Output: "secret://default/nginx/tls.key"}, d)
+
+	_, err = parseTemplateDescriptor("in.tmpl:out.txt?perms=999")
+	require.Error(t, err)
+
+	_, err = parseTemplateDescriptor("in.tmpl:out.txt?mode=0600")
+	require.Error(t, err)
+
+	_, err = parseTemplateDescriptor("in.tmpl")
+	require.Error(t, err)
+}
+
+func TestParsePerms(t *testing.T) {
+	p, err := parsePerms("0640")
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0640), p)
+
+	// YAML octal number
+	p, err = parsePerms(0600)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), p)
+
+	_, err = parsePerms("rw-r--r--")
+	require.Error(t, err)
+
+	_, err = parsePerms(01777)
+	require.Error(t, err)
+}
+
+func TestParseEnv(t *testing.T) {
+	env, err := parseEnv(map[interface{}]interface{}{"FOO": "bar", "NUM": 1})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"FOO": "bar", "NUM": "1"}, env)
+
+	_, err = parseEnv(map[string]interface{}{"A=B": "c"})
+	require.Error(t, err)
+
+	_, err = parseEnv("FOO=bar")
+	require.Error(t, err)
+}
+
+func TestParseReloadSignal(t *testing.T) {
+	a, err := parseReloadSignal(map[interface{}]interface{}{"pidfile": "/run/nginx.pid"})
+	require.NoError(t, err)
+	require.Equal(t, &SignalAction{PidFile: "/run/nginx.pid", Signal: syscall.SIGHUP}, a)
+
+	a, err = parseReloadSignal(map[interface{}]interface{}{"pidfile": "/run/nginx.pid", "signal": "quit"})
+	require.NoError(t, err)
+	require.Equal(t, &SignalAction{PidFile: "/run/nginx.pid", Signal: syscall.SIGQUIT}, a)
+
+	_, err = parseReloadSignal(map[interface{}]interface{}{"signal": "HUP"})
+	require.Error(t, err)
+
+	_, err = parseReloadSignal(map[interface{}]interface{}{"pidfile": "/run/nginx.pid", "signal": "FOO"})
+	require.Error(t, err)
+
+	_, err = parseReloadSignal("/run/nginx.pid")
+	require.Error(t, err)
+}
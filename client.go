@@ -15,9 +15,21 @@
 package main
 
 import (
-	v1 "k8s.io/api/core/v1"
+	"context"
+	"fmt"
+	"sort"
 	"sync"
 
+	"github.com/golang/glog"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/exec"
@@ -25,6 +37,7 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/openstack"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
@@ -34,14 +47,41 @@ const (
 
 type Client struct {
 	sync.RWMutex
-	kubeClient        kubernetes.Interface
-	stopCh            chan struct{}
-	useInformers      bool
-	informerFactories map[string]informers.SharedInformerFactory
-	listers           map[string]interface{}
+	kubeClient               kubernetes.Interface
+	dynamicClient            dynamic.Interface
+	stopCh                   chan struct{}
+	useInformers             bool
+	informerFactories        map[string]informers.SharedInformerFactory
+	dynamicInformerFactories map[string]dynamicinformer.DynamicSharedInformerFactory
+	listers                  map[string]interface{}
+	informers                map[string]int
+	updateCh                 chan struct{}
+	changesLock              sync.Mutex
+	changes                  []objectChange
+}
+
+// Kubernetes object change, as seen by informer
+type objectChange struct {
+	// Resource name (lowercase plural, e.g. 'pods')
+	resource string
+	// Object namespace
+	namespace string
+	// Object name
+	name string
+	// Object labels
+	labels labels.Set
 }
 
 func newClientForConfig(cfg *Config, stopCh chan struct{}) (*Client, error) {
+	if cfg.Fixtures != "" {
+		// Render templates offline
+		fixtures, err := loadFixtures(cfg.Fixtures, cfg.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		return newClientForFixtures(fixtures, stopCh, cfg.WatchEnabled())
+	}
+
 	var config *rest.Config
 	if cfg.GuessKubeAPISettings {
 		var err error
@@ -65,36 +105,309 @@ func newClientForConfig(cfg *Config, stopCh chan struct{}) (*Client, error) {
 		}
 	}
 
+	// Observe list requests, keeping transport wrappers already set (e.g. by auth providers)
+	config.Wrap(newMetricsRoundTripper)
+
 	c, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, err
 	}
 
-	return newClient(c, stopCh, cfg.PollingEnabled())
+	dc, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return newClient(c, dc, stopCh, cfg.WatchEnabled())
 }
 
-func newClient(c kubernetes.Interface, stopCh chan struct{}, useInformers bool) (*Client, error) {
-	return &Client{
-		kubeClient:        c,
-		stopCh:            stopCh,
-		useInformers:      useInformers,
-		informerFactories: make(map[string]informers.SharedInformerFactory),
-		listers:           make(map[string]interface{}),
-	}, nil
+func newClient(c kubernetes.Interface, dc dynamic.Interface, stopCh chan struct{}, useInformers bool) (*Client, error) {
+	client := &Client{
+		kubeClient:               c,
+		dynamicClient:            dc,
+		stopCh:                   stopCh,
+		useInformers:             useInformers,
+		informerFactories:        make(map[string]informers.SharedInformerFactory),
+		dynamicInformerFactories: make(map[string]dynamicinformer.DynamicSharedInformerFactory),
+		listers:                  make(map[string]interface{}),
+		informers:                make(map[string]int),
+		updateCh:                 make(chan struct{}, 1),
+	}
+	if stopCh != nil {
+		// Informers are stopped with client
+		go func() {
+			<-stopCh
+			client.Lock()
+			defer client.Unlock()
+			for namespace, count := range client.informers {
+				informersStopped(namespace, count)
+			}
+			client.informers = make(map[string]int)
+		}()
+	}
+	return client, nil
+}
+
+// Returns channel to receive notifications about Kubernetes objects updates
+func (c *Client) Updates() <-chan struct{} {
+	return c.updateCh
+}
+
+// Returns Kubernetes objects changes happened since last call
+func (c *Client) Changes() []objectChange {
+	c.changesLock.Lock()
+	defer c.changesLock.Unlock()
+	changes := c.changes
+	c.changes = nil
+	return changes
+}
+
+func (c *Client) notifyUpdate(resource string, objs ...interface{}) {
+	c.changesLock.Lock()
+	for _, obj := range objs {
+		if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = d.Obj
+		}
+		o, err := meta.Accessor(obj)
+		if err != nil {
+			glog.Warningf("can't get %s object metadata: %v", resource, err)
+			continue
+		}
+		c.changes = append(c.changes, objectChange{
+			resource:  resource,
+			namespace: o.GetNamespace(),
+			name:      o.GetName(),
+			labels:    labels.Set(o.GetLabels()),
+		})
+	}
+	c.changesLock.Unlock()
+	// Pending notification is enough to collapse a series of updates
+	select {
+	case c.updateCh <- struct{}{}:
+	default:
+	}
 }
 
-func (c *Client) informerFactory(namespace string) informers.SharedInformerFactory {
+// Informer event handler notifying about Kubernetes objects updates. Objects listed on informer
+// start are not notified as added, since they are seen by templates rendered after informer sync.
+type eventHandler struct {
+	client   *Client
+	resource string
+	lock     sync.Mutex
+	// Resource versions of objects listed on informer start by their keys, nil until informer synced
+	initial map[string]string
+}
+
+func (c *Client) eventHandler(resource string) *eventHandler {
+	return &eventHandler{client: c, resource: resource}
+}
+
+// Record objects listed on informer start, should be called after informer synced
+func (h *eventHandler) informerSynced(store cache.Store) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.initial = make(map[string]string)
+	for _, obj := range store.List() {
+		if o, err := meta.Accessor(obj); err == nil {
+			h.initial[objectKey(o)] = o.GetResourceVersion()
+		}
+	}
+}
+
+// Check object added is listed on informer start
+func (h *eventHandler) isInitial(obj interface{}) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.initial == nil {
+		// Informer is not synced yet, so object is listed on start
+		return true
+	}
+	o, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	key := objectKey(o)
+	if v, found := h.initial[key]; found && v == o.GetResourceVersion() {
+		// Object is added once
+		delete(h.initial, key)
+		return true
+	}
+	return false
+}
+
+// Returns object key in format 'namespace/name' ('name' for cluster-scoped objects)
+func objectKey(o metav1.Object) string {
+	if o.GetNamespace() == "" {
+		return o.GetName()
+	}
+	return o.GetNamespace() + "/" + o.GetName()
+}
+
+func (h *eventHandler) OnAdd(obj interface{}) {
+	if h.isInitial(obj) {
+		return
+	}
+	h.client.notifyUpdate(h.resource, obj)
+}
+
+func (h *eventHandler) OnUpdate(oldObj, newObj interface{}) {
+	// Object labels could be changed, so both objects are considered
+	h.client.notifyUpdate(h.resource, oldObj, newObj)
+}
+
+func (h *eventHandler) OnDelete(obj interface{}) {
+	h.client.notifyUpdate(h.resource, obj)
+}
+
+// Returns informer factory key for given namespace and field selector
+func informerFactoryKey(namespace, fieldSelector string) string {
+	if fieldSelector == "" {
+		return namespace
+	}
+	return fmt.Sprintf("%s(%s)", namespace, fieldSelector)
+}
+
+func (c *Client) informerFactory(namespace, fieldSelector string) informers.SharedInformerFactory {
 	if namespace == "" {
 		namespace = v1.NamespaceAll
 	}
 
-	informerFactory, found := c.informerFactories[namespace]
+	key := informerFactoryKey(namespace, fieldSelector)
+
+	// Factory is requested for each new informer
+	c.informerStarted(namespace)
+
+	informerFactory, found := c.informerFactories[key]
 
 	if !found {
-		informerFactory = informers.NewSharedInformerFactoryWithOptions(c.kubeClient, 0,
-			informers.WithNamespace(namespace))
-		c.informerFactories[namespace] = informerFactory
+		options := []informers.SharedInformerOption{informers.WithNamespace(namespace)}
+		if fieldSelector != "" {
+			// Objects are filtered by field selector on server side
+			options = append(options, informers.WithTweakListOptions(func(o *metav1.ListOptions) {
+				o.FieldSelector = fieldSelector
+			}))
+		}
+		informerFactory = informers.NewSharedInformerFactoryWithOptions(c.kubeClient, 0, options...)
+		c.informerFactories[key] = informerFactory
+	}
+
+	return informerFactory
+}
+
+func (c *Client) dynamicInformerFactory(namespace, fieldSelector string) dynamicinformer.DynamicSharedInformerFactory {
+	if namespace == "" {
+		namespace = v1.NamespaceAll
+	}
+
+	key := informerFactoryKey(namespace, fieldSelector)
+
+	// Factory is requested for each new informer
+	c.informerStarted(namespace)
+
+	informerFactory, found := c.dynamicInformerFactories[key]
+
+	if !found {
+		var tweakListOptions dynamicinformer.TweakListOptionsFunc
+		if fieldSelector != "" {
+			// Objects are filtered by field selector on server side
+			tweakListOptions = func(o *metav1.ListOptions) {
+				o.FieldSelector = fieldSelector
+			}
+		}
+		informerFactory = dynamicinformer.NewFilteredDynamicSharedInformerFactory(c.dynamicClient, 0,
+			namespace, tweakListOptions)
+		c.dynamicInformerFactories[key] = informerFactory
 	}
 
 	return informerFactory
 }
+
+// Count informer started in given namespace, should be called with lock held
+func (c *Client) informerStarted(namespace string) {
+	c.informers[namespace]++
+	informerStarted(namespace)
+}
+
+// Returns resource name in format 'group/version/resource' ('version/resource' for core group)
+func resourceName(gvr schema.GroupVersionResource) string {
+	return gvr.GroupVersion().String() + "/" + gvr.Resource
+}
+
+// Fetch arbitrary resources (e.g. custom ones) using dynamic client
+func (c *Client) Resources(gvr schema.GroupVersionResource, namespace, selector, fieldSelector string) ([]unstructured.Unstructured, error) {
+	resource := resourceName(gvr)
+
+	glog.V(4).Infof("fetching %s, namespace: %q, selector: %q, field selector: %q", resource, namespace, selector, fieldSelector)
+
+	var resources []unstructured.Unstructured
+
+	if c.useInformers {
+		c.Lock()
+		defer c.Unlock()
+
+		key := fmt.Sprintf("%s(%s,%s)", resource, namespace, fieldSelector)
+
+		resourceLister, found := c.listers[key]
+
+		if !found {
+			if fieldSelector != "" {
+				// Check field selector is supported by server before starting filtered informer
+				options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+				if _, err := c.dynamicClient.Resource(gvr).Namespace(namespace).List(context.TODO(), options); err != nil {
+					return nil, err
+				}
+			}
+
+			resourceInformer := c.dynamicInformerFactory(namespace, fieldSelector).ForResource(gvr)
+
+			resourceHandler := c.eventHandler(resource)
+
+			resourceInformer.Informer().AddEventHandler(resourceHandler)
+
+			resourceLister = resourceInformer.Lister()
+
+			c.listers[key] = resourceLister
+
+			go resourceInformer.Informer().Run(c.stopCh)
+
+			if synced := cache.WaitForCacheSync(c.stopCh, resourceInformer.Informer().HasSynced); !synced {
+				return nil, fmt.Errorf("%s cache sync failed", resource)
+			}
+
+			resourceHandler.informerSynced(resourceInformer.Informer().GetStore())
+		}
+
+		s, err := labels.Parse(selector)
+		if err != nil {
+			return nil, err
+		}
+
+		es, err := resourceLister.(cache.GenericLister).ByNamespace(namespace).List(s)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range es {
+			resources = append(resources, *e.(*unstructured.Unstructured))
+		}
+	} else {
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
+
+		resourceList, err := c.dynamicClient.Resource(gvr).Namespace(namespace).List(context.TODO(), options)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = resourceList.Items
+	}
+
+	// Make list order stable
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].GetNamespace() != resources[j].GetNamespace() {
+			return resources[i].GetNamespace() < resources[j].GetNamespace()
+		}
+		return resources[i].GetName() < resources[j].GetName()
+	})
+
+	return resources, nil
+}
@@ -22,40 +22,65 @@ import (
 	"fmt"
 	"sort"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/client-go/listers/core/v1"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/golang/glog"
 )
 
-func (c *Client) Pods(namespace, selector string) ([]corev1.Pod, error) {
-	glog.V(4).Infof("fetching pods, namespace: %q, selector: %q", namespace, selector)
+func (c *Client) podLister(namespace, fieldSelector string) (corev1listers.PodLister, error) {
+	c.Lock()
+	defer c.Unlock()
 
-	var pods []corev1.Pod
+	key := fmt.Sprintf("pods(%s,%s)", namespace, fieldSelector)
 
-	if c.useInformers {
-		c.Lock()
-		defer c.Unlock()
+	podLister, found := c.listers[key]
+
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.CoreV1().Pods(namespace).List(context.TODO(), options); err != nil {
+				return nil, err
+			}
+		}
 
-		key := fmt.Sprintf("pods(%s)", namespace)
+		podInformer := c.informerFactory(namespace, fieldSelector).Core().V1().Pods()
 
-		podLister, found := c.listers[key]
+		podHandler := c.eventHandler("pods")
 
-		if !found {
-			podInformer := c.informerFactory(namespace).Core().V1().Pods()
+		podInformer.Informer().AddEventHandler(podHandler)
 
-			podLister = podInformer.Lister()
+		podLister = podInformer.Lister()
 
-			c.listers[key] = podLister
+		c.listers[key] = podLister
 
-			go podInformer.Informer().Run(c.stopCh)
+		go podInformer.Informer().Run(c.stopCh)
 
-			if synced := cache.WaitForCacheSync(c.stopCh, podInformer.Informer().HasSynced); !synced {
-				return nil, errors.New("pod cache sync failed")
-			}
+		if synced := cache.WaitForCacheSync(c.stopCh, podInformer.Informer().HasSynced); !synced {
+			return nil, errors.New("pod cache sync failed")
+		}
+
+		podHandler.informerSynced(podInformer.Informer().GetStore())
+	}
+
+	return podLister.(corev1listers.PodLister), nil
+}
+
+func (c *Client) Pods(namespace, selector, fieldSelector string) ([]corev1.Pod, error) {
+	glog.V(4).Infof("fetching pods, namespace: %q, selector: %q, field selector: %q", namespace, selector, fieldSelector)
+
+	var pods []corev1.Pod
+
+	if c.useInformers {
+		podLister, err := c.podLister(namespace, fieldSelector)
+		if err != nil {
+			return nil, err
 		}
 
 		s, err := labels.Parse(selector)
@@ -63,7 +88,7 @@ func (c *Client) Pods(namespace, selector string) ([]corev1.Pod, error) {
 			return nil, err
 		}
 
-		es, err := podLister.(v1.PodLister).Pods(namespace).List(s)
+		es, err := podLister.Pods(namespace).List(s)
 		if err != nil {
 			return nil, err
 		}
@@ -72,7 +97,7 @@ func (c *Client) Pods(namespace, selector string) ([]corev1.Pod, error) {
 			pods = append(pods, *e)
 		}
 	} else {
-		options := metav1.ListOptions{LabelSelector: selector}
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
 
 		podList, err := c.kubeClient.CoreV1().Pods(namespace).List(context.TODO(), options)
 		if err != nil {
@@ -84,45 +109,86 @@ func (c *Client) Pods(namespace, selector string) ([]corev1.Pod, error) {
 
 	// Make list order stable
 	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
 		return pods[i].Name < pods[j].Name
 	})
 
 	return pods, nil
 }
 
-func (c *Client) Services(namespace, selector string) ([]corev1.Service, error) {
-	glog.V(4).Infof("fetching services, namespace: %q, selector: %q", namespace, selector)
-
-	var services []corev1.Service
+func (c *Client) Pod(namespace, name string) (*corev1.Pod, error) {
+	glog.V(4).Infof("fetching pod, namespace: %q, name: %q", namespace, name)
 
 	if c.useInformers {
-		c.Lock()
-		defer c.Unlock()
-
-		key := fmt.Sprintf("services(%s)", namespace)
+		podLister, err := c.podLister(namespace, "")
+		if err != nil {
+			return nil, err
+		}
 
-		serviceLister, found := c.listers[key]
+		return podLister.Pods(namespace).Get(name)
+	}
 
-		if !found {
-			serviceInformer := c.informerFactory(namespace).Core().V1().Services()
+	return c.kubeClient.CoreV1().Pods(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
 
-			serviceLister = serviceInformer.Lister()
+func (c *Client) serviceLister(namespace, fieldSelector string) (corev1listers.ServiceLister, error) {
+	c.Lock()
+	defer c.Unlock()
 
-			c.listers[key] = serviceLister
+	key := fmt.Sprintf("services(%s,%s)", namespace, fieldSelector)
 
-			go serviceInformer.Informer().Run(c.stopCh)
+	serviceLister, found := c.listers[key]
 
-			if synced := cache.WaitForCacheSync(c.stopCh, serviceInformer.Informer().HasSynced); !synced {
-				return nil, errors.New("service cache sync failed")
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.CoreV1().Services(namespace).List(context.TODO(), options); err != nil {
+				return nil, err
 			}
 		}
 
+		serviceInformer := c.informerFactory(namespace, fieldSelector).Core().V1().Services()
+
+		serviceHandler := c.eventHandler("services")
+
+		serviceInformer.Informer().AddEventHandler(serviceHandler)
+
+		serviceLister = serviceInformer.Lister()
+
+		c.listers[key] = serviceLister
+
+		go serviceInformer.Informer().Run(c.stopCh)
+
+		if synced := cache.WaitForCacheSync(c.stopCh, serviceInformer.Informer().HasSynced); !synced {
+			return nil, errors.New("service cache sync failed")
+		}
+
+		serviceHandler.informerSynced(serviceInformer.Informer().GetStore())
+	}
+
+	return serviceLister.(corev1listers.ServiceLister), nil
+}
+
+func (c *Client) Services(namespace, selector, fieldSelector string) ([]corev1.Service, error) {
+	glog.V(4).Infof("fetching services, namespace: %q, selector: %q, field selector: %q", namespace, selector, fieldSelector)
+
+	var services []corev1.Service
+
+	if c.useInformers {
+		serviceLister, err := c.serviceLister(namespace, fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+
 		s, err := labels.Parse(selector)
 		if err != nil {
 			return nil, err
 		}
 
-		es, err := serviceLister.(v1.ServiceLister).Services(namespace).List(s)
+		es, err := serviceLister.Services(namespace).List(s)
 		if err != nil {
 			return nil, err
 		}
@@ -131,7 +197,7 @@ func (c *Client) Services(namespace, selector string) ([]corev1.Service, error)
 			services = append(services, *e)
 		}
 	} else {
-		options := metav1.ListOptions{LabelSelector: selector}
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
 
 		serviceList, err := c.kubeClient.CoreV1().Services(namespace).List(context.TODO(), options)
 		if err != nil {
@@ -143,45 +209,86 @@ func (c *Client) Services(namespace, selector string) ([]corev1.Service, error)
 
 	// Make list order stable
 	sort.Slice(services, func(i, j int) bool {
+		if services[i].Namespace != services[j].Namespace {
+			return services[i].Namespace < services[j].Namespace
+		}
 		return services[i].Name < services[j].Name
 	})
 
 	return services, nil
 }
 
-func (c *Client) ReplicationControllers(namespace, selector string) ([]corev1.ReplicationController, error) {
-	glog.V(4).Infof("fetching replicationcontrollers, namespace: %q, selector: %q", namespace, selector)
-
-	var replicationcontrollers []corev1.ReplicationController
+func (c *Client) Service(namespace, name string) (*corev1.Service, error) {
+	glog.V(4).Infof("fetching service, namespace: %q, name: %q", namespace, name)
 
 	if c.useInformers {
-		c.Lock()
-		defer c.Unlock()
-
-		key := fmt.Sprintf("replicationcontrollers(%s)", namespace)
+		serviceLister, err := c.serviceLister(namespace, "")
+		if err != nil {
+			return nil, err
+		}
 
-		replicationcontrollerLister, found := c.listers[key]
+		return serviceLister.Services(namespace).Get(name)
+	}
 
-		if !found {
-			replicationcontrollerInformer := c.informerFactory(namespace).Core().V1().ReplicationControllers()
+	return c.kubeClient.CoreV1().Services(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
 
-			replicationcontrollerLister = replicationcontrollerInformer.Lister()
+func (c *Client) replicationcontrollerLister(namespace, fieldSelector string) (corev1listers.ReplicationControllerLister, error) {
+	c.Lock()
+	defer c.Unlock()
 
-			c.listers[key] = replicationcontrollerLister
+	key := fmt.Sprintf("replicationcontrollers(%s,%s)", namespace, fieldSelector)
 
-			go replicationcontrollerInformer.Informer().Run(c.stopCh)
+	replicationcontrollerLister, found := c.listers[key]
 
-			if synced := cache.WaitForCacheSync(c.stopCh, replicationcontrollerInformer.Informer().HasSynced); !synced {
-				return nil, errors.New("replicationcontroller cache sync failed")
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.CoreV1().ReplicationControllers(namespace).List(context.TODO(), options); err != nil {
+				return nil, err
 			}
 		}
 
+		replicationcontrollerInformer := c.informerFactory(namespace, fieldSelector).Core().V1().ReplicationControllers()
+
+		replicationcontrollerHandler := c.eventHandler("replicationcontrollers")
+
+		replicationcontrollerInformer.Informer().AddEventHandler(replicationcontrollerHandler)
+
+		replicationcontrollerLister = replicationcontrollerInformer.Lister()
+
+		c.listers[key] = replicationcontrollerLister
+
+		go replicationcontrollerInformer.Informer().Run(c.stopCh)
+
+		if synced := cache.WaitForCacheSync(c.stopCh, replicationcontrollerInformer.Informer().HasSynced); !synced {
+			return nil, errors.New("replicationcontroller cache sync failed")
+		}
+
+		replicationcontrollerHandler.informerSynced(replicationcontrollerInformer.Informer().GetStore())
+	}
+
+	return replicationcontrollerLister.(corev1listers.ReplicationControllerLister), nil
+}
+
+func (c *Client) ReplicationControllers(namespace, selector, fieldSelector string) ([]corev1.ReplicationController, error) {
+	glog.V(4).Infof("fetching replicationcontrollers, namespace: %q, selector: %q, field selector: %q", namespace, selector, fieldSelector)
+
+	var replicationcontrollers []corev1.ReplicationController
+
+	if c.useInformers {
+		replicationcontrollerLister, err := c.replicationcontrollerLister(namespace, fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+
 		s, err := labels.Parse(selector)
 		if err != nil {
 			return nil, err
 		}
 
-		es, err := replicationcontrollerLister.(v1.ReplicationControllerLister).ReplicationControllers(namespace).List(s)
+		es, err := replicationcontrollerLister.ReplicationControllers(namespace).List(s)
 		if err != nil {
 			return nil, err
 		}
@@ -190,7 +297,7 @@ func (c *Client) ReplicationControllers(namespace, selector string) ([]corev1.Re
 			replicationcontrollers = append(replicationcontrollers, *e)
 		}
 	} else {
-		options := metav1.ListOptions{LabelSelector: selector}
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
 
 		replicationcontrollerList, err := c.kubeClient.CoreV1().ReplicationControllers(namespace).List(context.TODO(), options)
 		if err != nil {
@@ -202,45 +309,86 @@ func (c *Client) ReplicationControllers(namespace, selector string) ([]corev1.Re
 
 	// Make list order stable
 	sort.Slice(replicationcontrollers, func(i, j int) bool {
+		if replicationcontrollers[i].Namespace != replicationcontrollers[j].Namespace {
+			return replicationcontrollers[i].Namespace < replicationcontrollers[j].Namespace
+		}
 		return replicationcontrollers[i].Name < replicationcontrollers[j].Name
 	})
 
 	return replicationcontrollers, nil
 }
 
-func (c *Client) Events(namespace, selector string) ([]corev1.Event, error) {
-	glog.V(4).Infof("fetching events, namespace: %q, selector: %q", namespace, selector)
-
-	var events []corev1.Event
+func (c *Client) ReplicationController(namespace, name string) (*corev1.ReplicationController, error) {
+	glog.V(4).Infof("fetching replicationcontroller, namespace: %q, name: %q", namespace, name)
 
 	if c.useInformers {
-		c.Lock()
-		defer c.Unlock()
-
-		key := fmt.Sprintf("events(%s)", namespace)
+		replicationcontrollerLister, err := c.replicationcontrollerLister(namespace, "")
+		if err != nil {
+			return nil, err
+		}
 
-		eventLister, found := c.listers[key]
+		return replicationcontrollerLister.ReplicationControllers(namespace).Get(name)
+	}
 
-		if !found {
-			eventInformer := c.informerFactory(namespace).Core().V1().Events()
+	return c.kubeClient.CoreV1().ReplicationControllers(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
 
-			eventLister = eventInformer.Lister()
+func (c *Client) eventLister(namespace, fieldSelector string) (corev1listers.EventLister, error) {
+	c.Lock()
+	defer c.Unlock()
 
-			c.listers[key] = eventLister
+	key := fmt.Sprintf("events(%s,%s)", namespace, fieldSelector)
 
-			go eventInformer.Informer().Run(c.stopCh)
+	eventLister, found := c.listers[key]
 
-			if synced := cache.WaitForCacheSync(c.stopCh, eventInformer.Informer().HasSynced); !synced {
-				return nil, errors.New("event cache sync failed")
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.CoreV1().Events(namespace).List(context.TODO(), options); err != nil {
+				return nil, err
 			}
 		}
 
+		eventInformer := c.informerFactory(namespace, fieldSelector).Core().V1().Events()
+
+		eventHandler := c.eventHandler("events")
+
+		eventInformer.Informer().AddEventHandler(eventHandler)
+
+		eventLister = eventInformer.Lister()
+
+		c.listers[key] = eventLister
+
+		go eventInformer.Informer().Run(c.stopCh)
+
+		if synced := cache.WaitForCacheSync(c.stopCh, eventInformer.Informer().HasSynced); !synced {
+			return nil, errors.New("event cache sync failed")
+		}
+
+		eventHandler.informerSynced(eventInformer.Informer().GetStore())
+	}
+
+	return eventLister.(corev1listers.EventLister), nil
+}
+
+func (c *Client) Events(namespace, selector, fieldSelector string) ([]corev1.Event, error) {
+	glog.V(4).Infof("fetching events, namespace: %q, selector: %q, field selector: %q", namespace, selector, fieldSelector)
+
+	var events []corev1.Event
+
+	if c.useInformers {
+		eventLister, err := c.eventLister(namespace, fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+
 		s, err := labels.Parse(selector)
 		if err != nil {
 			return nil, err
 		}
 
-		es, err := eventLister.(v1.EventLister).Events(namespace).List(s)
+		es, err := eventLister.Events(namespace).List(s)
 		if err != nil {
 			return nil, err
 		}
@@ -249,7 +397,7 @@ func (c *Client) Events(namespace, selector string) ([]corev1.Event, error) {
 			events = append(events, *e)
 		}
 	} else {
-		options := metav1.ListOptions{LabelSelector: selector}
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
 
 		eventList, err := c.kubeClient.CoreV1().Events(namespace).List(context.TODO(), options)
 		if err != nil {
@@ -261,45 +409,86 @@ func (c *Client) Events(namespace, selector string) ([]corev1.Event, error) {
 
 	// Make list order stable
 	sort.Slice(events, func(i, j int) bool {
+		if events[i].Namespace != events[j].Namespace {
+			return events[i].Namespace < events[j].Namespace
+		}
 		return events[i].Name < events[j].Name
 	})
 
 	return events, nil
 }
 
-func (c *Client) Endpoints(namespace, selector string) ([]corev1.Endpoints, error) {
-	glog.V(4).Infof("fetching endpoints, namespace: %q, selector: %q", namespace, selector)
-
-	var endpoints []corev1.Endpoints
+func (c *Client) Event(namespace, name string) (*corev1.Event, error) {
+	glog.V(4).Infof("fetching event, namespace: %q, name: %q", namespace, name)
 
 	if c.useInformers {
-		c.Lock()
-		defer c.Unlock()
-
-		key := fmt.Sprintf("endpoints(%s)", namespace)
+		eventLister, err := c.eventLister(namespace, "")
+		if err != nil {
+			return nil, err
+		}
 
-		endpointsLister, found := c.listers[key]
+		return eventLister.Events(namespace).Get(name)
+	}
 
-		if !found {
-			endpointsInformer := c.informerFactory(namespace).Core().V1().Endpoints()
+	return c.kubeClient.CoreV1().Events(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
 
-			endpointsLister = endpointsInformer.Lister()
+func (c *Client) endpointsLister(namespace, fieldSelector string) (corev1listers.EndpointsLister, error) {
+	c.Lock()
+	defer c.Unlock()
 
-			c.listers[key] = endpointsLister
+	key := fmt.Sprintf("endpoints(%s,%s)", namespace, fieldSelector)
 
-			go endpointsInformer.Informer().Run(c.stopCh)
+	endpointsLister, found := c.listers[key]
 
-			if synced := cache.WaitForCacheSync(c.stopCh, endpointsInformer.Informer().HasSynced); !synced {
-				return nil, errors.New("endpoints cache sync failed")
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.CoreV1().Endpoints(namespace).List(context.TODO(), options); err != nil {
+				return nil, err
 			}
 		}
 
+		endpointsInformer := c.informerFactory(namespace, fieldSelector).Core().V1().Endpoints()
+
+		endpointsHandler := c.eventHandler("endpoints")
+
+		endpointsInformer.Informer().AddEventHandler(endpointsHandler)
+
+		endpointsLister = endpointsInformer.Lister()
+
+		c.listers[key] = endpointsLister
+
+		go endpointsInformer.Informer().Run(c.stopCh)
+
+		if synced := cache.WaitForCacheSync(c.stopCh, endpointsInformer.Informer().HasSynced); !synced {
+			return nil, errors.New("endpoints cache sync failed")
+		}
+
+		endpointsHandler.informerSynced(endpointsInformer.Informer().GetStore())
+	}
+
+	return endpointsLister.(corev1listers.EndpointsLister), nil
+}
+
+func (c *Client) Endpoints(namespace, selector, fieldSelector string) ([]corev1.Endpoints, error) {
+	glog.V(4).Infof("fetching endpoints, namespace: %q, selector: %q, field selector: %q", namespace, selector, fieldSelector)
+
+	var endpoints []corev1.Endpoints
+
+	if c.useInformers {
+		endpointsLister, err := c.endpointsLister(namespace, fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+
 		s, err := labels.Parse(selector)
 		if err != nil {
 			return nil, err
 		}
 
-		es, err := endpointsLister.(v1.EndpointsLister).Endpoints(namespace).List(s)
+		es, err := endpointsLister.Endpoints(namespace).List(s)
 		if err != nil {
 			return nil, err
 		}
@@ -308,7 +497,7 @@ func (c *Client) Endpoints(namespace, selector string) ([]corev1.Endpoints, erro
 			endpoints = append(endpoints, *e)
 		}
 	} else {
-		options := metav1.ListOptions{LabelSelector: selector}
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
 
 		endpointsList, err := c.kubeClient.CoreV1().Endpoints(namespace).List(context.TODO(), options)
 		if err != nil {
@@ -320,45 +509,86 @@ func (c *Client) Endpoints(namespace, selector string) ([]corev1.Endpoints, erro
 
 	// Make list order stable
 	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].Namespace != endpoints[j].Namespace {
+			return endpoints[i].Namespace < endpoints[j].Namespace
+		}
 		return endpoints[i].Name < endpoints[j].Name
 	})
 
 	return endpoints, nil
 }
 
-func (c *Client) Nodes(selector string) ([]corev1.Node, error) {
-	glog.V(4).Infof("fetching nodes, selector: %q", selector)
-
-	var nodes []corev1.Node
+func (c *Client) Endpoint(namespace, name string) (*corev1.Endpoints, error) {
+	glog.V(4).Infof("fetching endpoint, namespace: %q, name: %q", namespace, name)
 
 	if c.useInformers {
-		c.Lock()
-		defer c.Unlock()
-
-		key := fmt.Sprintf("nodes()")
+		endpointsLister, err := c.endpointsLister(namespace, "")
+		if err != nil {
+			return nil, err
+		}
 
-		nodeLister, found := c.listers[key]
+		return endpointsLister.Endpoints(namespace).Get(name)
+	}
 
-		if !found {
-			nodeInformer := c.informerFactory("").Core().V1().Nodes()
+	return c.kubeClient.CoreV1().Endpoints(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
 
-			nodeLister = nodeInformer.Lister()
+func (c *Client) nodeLister(fieldSelector string) (corev1listers.NodeLister, error) {
+	c.Lock()
+	defer c.Unlock()
 
-			c.listers[key] = nodeLister
+	key := fmt.Sprintf("nodes(%s)", fieldSelector)
 
-			go nodeInformer.Informer().Run(c.stopCh)
+	nodeLister, found := c.listers[key]
 
-			if synced := cache.WaitForCacheSync(c.stopCh, nodeInformer.Informer().HasSynced); !synced {
-				return nil, errors.New("node cache sync failed")
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.CoreV1().Nodes().List(context.TODO(), options); err != nil {
+				return nil, err
 			}
 		}
 
+		nodeInformer := c.informerFactory("", fieldSelector).Core().V1().Nodes()
+
+		nodeHandler := c.eventHandler("nodes")
+
+		nodeInformer.Informer().AddEventHandler(nodeHandler)
+
+		nodeLister = nodeInformer.Lister()
+
+		c.listers[key] = nodeLister
+
+		go nodeInformer.Informer().Run(c.stopCh)
+
+		if synced := cache.WaitForCacheSync(c.stopCh, nodeInformer.Informer().HasSynced); !synced {
+			return nil, errors.New("node cache sync failed")
+		}
+
+		nodeHandler.informerSynced(nodeInformer.Informer().GetStore())
+	}
+
+	return nodeLister.(corev1listers.NodeLister), nil
+}
+
+func (c *Client) Nodes(selector, fieldSelector string) ([]corev1.Node, error) {
+	glog.V(4).Infof("fetching nodes, selector: %q, field selector: %q", selector, fieldSelector)
+
+	var nodes []corev1.Node
+
+	if c.useInformers {
+		nodeLister, err := c.nodeLister(fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+
 		s, err := labels.Parse(selector)
 		if err != nil {
 			return nil, err
 		}
 
-		es, err := nodeLister.(v1.NodeLister).List(s)
+		es, err := nodeLister.List(s)
 		if err != nil {
 			return nil, err
 		}
@@ -367,7 +597,7 @@ func (c *Client) Nodes(selector string) ([]corev1.Node, error) {
 			nodes = append(nodes, *e)
 		}
 	} else {
-		options := metav1.ListOptions{LabelSelector: selector}
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
 
 		nodeList, err := c.kubeClient.CoreV1().Nodes().List(context.TODO(), options)
 		if err != nil {
@@ -379,45 +609,86 @@ func (c *Client) Nodes(selector string) ([]corev1.Node, error) {
 
 	// Make list order stable
 	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Namespace != nodes[j].Namespace {
+			return nodes[i].Namespace < nodes[j].Namespace
+		}
 		return nodes[i].Name < nodes[j].Name
 	})
 
 	return nodes, nil
 }
 
-func (c *Client) Namespaces(selector string) ([]corev1.Namespace, error) {
-	glog.V(4).Infof("fetching namespaces, selector: %q", selector)
-
-	var namespaces []corev1.Namespace
+func (c *Client) Node(name string) (*corev1.Node, error) {
+	glog.V(4).Infof("fetching node, name: %q", name)
 
 	if c.useInformers {
-		c.Lock()
-		defer c.Unlock()
-
-		key := fmt.Sprintf("namespaces()")
+		nodeLister, err := c.nodeLister("")
+		if err != nil {
+			return nil, err
+		}
 
-		namespaceLister, found := c.listers[key]
+		return nodeLister.Get(name)
+	}
 
-		if !found {
-			namespaceInformer := c.informerFactory("").Core().V1().Namespaces()
+	return c.kubeClient.CoreV1().Nodes().Get(context.TODO(), name, metav1.GetOptions{})
+}
 
-			namespaceLister = namespaceInformer.Lister()
+func (c *Client) namespaceLister(fieldSelector string) (corev1listers.NamespaceLister, error) {
+	c.Lock()
+	defer c.Unlock()
 
-			c.listers[key] = namespaceLister
+	key := fmt.Sprintf("namespaces(%s)", fieldSelector)
 
-			go namespaceInformer.Informer().Run(c.stopCh)
+	namespaceLister, found := c.listers[key]
 
-			if synced := cache.WaitForCacheSync(c.stopCh, namespaceInformer.Informer().HasSynced); !synced {
-				return nil, errors.New("namespace cache sync failed")
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.CoreV1().Namespaces().List(context.TODO(), options); err != nil {
+				return nil, err
 			}
 		}
 
+		namespaceInformer := c.informerFactory("", fieldSelector).Core().V1().Namespaces()
+
+		namespaceHandler := c.eventHandler("namespaces")
+
+		namespaceInformer.Informer().AddEventHandler(namespaceHandler)
+
+		namespaceLister = namespaceInformer.Lister()
+
+		c.listers[key] = namespaceLister
+
+		go namespaceInformer.Informer().Run(c.stopCh)
+
+		if synced := cache.WaitForCacheSync(c.stopCh, namespaceInformer.Informer().HasSynced); !synced {
+			return nil, errors.New("namespace cache sync failed")
+		}
+
+		namespaceHandler.informerSynced(namespaceInformer.Informer().GetStore())
+	}
+
+	return namespaceLister.(corev1listers.NamespaceLister), nil
+}
+
+func (c *Client) Namespaces(selector, fieldSelector string) ([]corev1.Namespace, error) {
+	glog.V(4).Infof("fetching namespaces, selector: %q, field selector: %q", selector, fieldSelector)
+
+	var namespaces []corev1.Namespace
+
+	if c.useInformers {
+		namespaceLister, err := c.namespaceLister(fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+
 		s, err := labels.Parse(selector)
 		if err != nil {
 			return nil, err
 		}
 
-		es, err := namespaceLister.(v1.NamespaceLister).List(s)
+		es, err := namespaceLister.List(s)
 		if err != nil {
 			return nil, err
 		}
@@ -426,7 +697,7 @@ func (c *Client) Namespaces(selector string) ([]corev1.Namespace, error) {
 			namespaces = append(namespaces, *e)
 		}
 	} else {
-		options := metav1.ListOptions{LabelSelector: selector}
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
 
 		namespaceList, err := c.kubeClient.CoreV1().Namespaces().List(context.TODO(), options)
 		if err != nil {
@@ -438,45 +709,86 @@ func (c *Client) Namespaces(selector string) ([]corev1.Namespace, error) {
 
 	// Make list order stable
 	sort.Slice(namespaces, func(i, j int) bool {
+		if namespaces[i].Namespace != namespaces[j].Namespace {
+			return namespaces[i].Namespace < namespaces[j].Namespace
+		}
 		return namespaces[i].Name < namespaces[j].Name
 	})
 
 	return namespaces, nil
 }
 
-func (c *Client) ComponentStatuses(selector string) ([]corev1.ComponentStatus, error) {
-	glog.V(4).Infof("fetching componentstatuses, selector: %q", selector)
-
-	var componentstatuses []corev1.ComponentStatus
+func (c *Client) Namespace(name string) (*corev1.Namespace, error) {
+	glog.V(4).Infof("fetching namespace, name: %q", name)
 
 	if c.useInformers {
-		c.Lock()
-		defer c.Unlock()
-
-		key := fmt.Sprintf("componentstatuses()")
+		namespaceLister, err := c.namespaceLister("")
+		if err != nil {
+			return nil, err
+		}
 
-		componentstatusLister, found := c.listers[key]
+		return namespaceLister.Get(name)
+	}
 
-		if !found {
-			componentstatusInformer := c.informerFactory("").Core().V1().ComponentStatuses()
+	return c.kubeClient.CoreV1().Namespaces().Get(context.TODO(), name, metav1.GetOptions{})
+}
 
-			componentstatusLister = componentstatusInformer.Lister()
+func (c *Client) componentstatusLister(fieldSelector string) (corev1listers.ComponentStatusLister, error) {
+	c.Lock()
+	defer c.Unlock()
 
-			c.listers[key] = componentstatusLister
+	key := fmt.Sprintf("componentstatuses(%s)", fieldSelector)
 
-			go componentstatusInformer.Informer().Run(c.stopCh)
+	componentstatusLister, found := c.listers[key]
 
-			if synced := cache.WaitForCacheSync(c.stopCh, componentstatusInformer.Informer().HasSynced); !synced {
-				return nil, errors.New("componentstatus cache sync failed")
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.CoreV1().ComponentStatuses().List(context.TODO(), options); err != nil {
+				return nil, err
 			}
 		}
 
+		componentstatusInformer := c.informerFactory("", fieldSelector).Core().V1().ComponentStatuses()
+
+		componentstatusHandler := c.eventHandler("componentstatuses")
+
+		componentstatusInformer.Informer().AddEventHandler(componentstatusHandler)
+
+		componentstatusLister = componentstatusInformer.Lister()
+
+		c.listers[key] = componentstatusLister
+
+		go componentstatusInformer.Informer().Run(c.stopCh)
+
+		if synced := cache.WaitForCacheSync(c.stopCh, componentstatusInformer.Informer().HasSynced); !synced {
+			return nil, errors.New("componentstatus cache sync failed")
+		}
+
+		componentstatusHandler.informerSynced(componentstatusInformer.Informer().GetStore())
+	}
+
+	return componentstatusLister.(corev1listers.ComponentStatusLister), nil
+}
+
+func (c *Client) ComponentStatuses(selector, fieldSelector string) ([]corev1.ComponentStatus, error) {
+	glog.V(4).Infof("fetching componentstatuses, selector: %q, field selector: %q", selector, fieldSelector)
+
+	var componentstatuses []corev1.ComponentStatus
+
+	if c.useInformers {
+		componentstatusLister, err := c.componentstatusLister(fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+
 		s, err := labels.Parse(selector)
 		if err != nil {
 			return nil, err
 		}
 
-		es, err := componentstatusLister.(v1.ComponentStatusLister).List(s)
+		es, err := componentstatusLister.List(s)
 		if err != nil {
 			return nil, err
 		}
@@ -485,7 +797,7 @@ func (c *Client) ComponentStatuses(selector string) ([]corev1.ComponentStatus, e
 			componentstatuses = append(componentstatuses, *e)
 		}
 	} else {
-		options := metav1.ListOptions{LabelSelector: selector}
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
 
 		componentstatusList, err := c.kubeClient.CoreV1().ComponentStatuses().List(context.TODO(), options)
 		if err != nil {
@@ -497,45 +809,86 @@ func (c *Client) ComponentStatuses(selector string) ([]corev1.ComponentStatus, e
 
 	// Make list order stable
 	sort.Slice(componentstatuses, func(i, j int) bool {
+		if componentstatuses[i].Namespace != componentstatuses[j].Namespace {
+			return componentstatuses[i].Namespace < componentstatuses[j].Namespace
+		}
 		return componentstatuses[i].Name < componentstatuses[j].Name
 	})
 
 	return componentstatuses, nil
 }
 
-func (c *Client) ConfigMaps(namespace, selector string) ([]corev1.ConfigMap, error) {
-	glog.V(4).Infof("fetching configmaps, namespace: %q, selector: %q", namespace, selector)
-
-	var configmaps []corev1.ConfigMap
+func (c *Client) ComponentStatus(name string) (*corev1.ComponentStatus, error) {
+	glog.V(4).Infof("fetching componentstatus, name: %q", name)
 
 	if c.useInformers {
-		c.Lock()
-		defer c.Unlock()
-
-		key := fmt.Sprintf("configmaps(%s)", namespace)
+		componentstatusLister, err := c.componentstatusLister("")
+		if err != nil {
+			return nil, err
+		}
 
-		configmapLister, found := c.listers[key]
+		return componentstatusLister.Get(name)
+	}
 
-		if !found {
-			configmapInformer := c.informerFactory(namespace).Core().V1().ConfigMaps()
+	return c.kubeClient.CoreV1().ComponentStatuses().Get(context.TODO(), name, metav1.GetOptions{})
+}
 
-			configmapLister = configmapInformer.Lister()
+func (c *Client) configmapLister(namespace, fieldSelector string) (corev1listers.ConfigMapLister, error) {
+	c.Lock()
+	defer c.Unlock()
 
-			c.listers[key] = configmapLister
+	key := fmt.Sprintf("configmaps(%s,%s)", namespace, fieldSelector)
 
-			go configmapInformer.Informer().Run(c.stopCh)
+	configmapLister, found := c.listers[key]
 
-			if synced := cache.WaitForCacheSync(c.stopCh, configmapInformer.Informer().HasSynced); !synced {
-				return nil, errors.New("configmap cache sync failed")
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.CoreV1().ConfigMaps(namespace).List(context.TODO(), options); err != nil {
+				return nil, err
 			}
 		}
 
+		configmapInformer := c.informerFactory(namespace, fieldSelector).Core().V1().ConfigMaps()
+
+		configmapHandler := c.eventHandler("configmaps")
+
+		configmapInformer.Informer().AddEventHandler(configmapHandler)
+
+		configmapLister = configmapInformer.Lister()
+
+		c.listers[key] = configmapLister
+
+		go configmapInformer.Informer().Run(c.stopCh)
+
+		if synced := cache.WaitForCacheSync(c.stopCh, configmapInformer.Informer().HasSynced); !synced {
+			return nil, errors.New("configmap cache sync failed")
+		}
+
+		configmapHandler.informerSynced(configmapInformer.Informer().GetStore())
+	}
+
+	return configmapLister.(corev1listers.ConfigMapLister), nil
+}
+
+func (c *Client) ConfigMaps(namespace, selector, fieldSelector string) ([]corev1.ConfigMap, error) {
+	glog.V(4).Infof("fetching configmaps, namespace: %q, selector: %q, field selector: %q", namespace, selector, fieldSelector)
+
+	var configmaps []corev1.ConfigMap
+
+	if c.useInformers {
+		configmapLister, err := c.configmapLister(namespace, fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+
 		s, err := labels.Parse(selector)
 		if err != nil {
 			return nil, err
 		}
 
-		es, err := configmapLister.(v1.ConfigMapLister).ConfigMaps(namespace).List(s)
+		es, err := configmapLister.ConfigMaps(namespace).List(s)
 		if err != nil {
 			return nil, err
 		}
@@ -544,7 +897,7 @@ func (c *Client) ConfigMaps(namespace, selector string) ([]corev1.ConfigMap, err
 			configmaps = append(configmaps, *e)
 		}
 	} else {
-		options := metav1.ListOptions{LabelSelector: selector}
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
 
 		configmapList, err := c.kubeClient.CoreV1().ConfigMaps(namespace).List(context.TODO(), options)
 		if err != nil {
@@ -556,45 +909,86 @@ func (c *Client) ConfigMaps(namespace, selector string) ([]corev1.ConfigMap, err
 
 	// Make list order stable
 	sort.Slice(configmaps, func(i, j int) bool {
+		if configmaps[i].Namespace != configmaps[j].Namespace {
+			return configmaps[i].Namespace < configmaps[j].Namespace
+		}
 		return configmaps[i].Name < configmaps[j].Name
 	})
 
 	return configmaps, nil
 }
 
-func (c *Client) LimitRanges(namespace, selector string) ([]corev1.LimitRange, error) {
-	glog.V(4).Infof("fetching limitranges, namespace: %q, selector: %q", namespace, selector)
-
-	var limitranges []corev1.LimitRange
+func (c *Client) ConfigMap(namespace, name string) (*corev1.ConfigMap, error) {
+	glog.V(4).Infof("fetching configmap, namespace: %q, name: %q", namespace, name)
 
 	if c.useInformers {
-		c.Lock()
-		defer c.Unlock()
+		configmapLister, err := c.configmapLister(namespace, "")
+		if err != nil {
+			return nil, err
+		}
 
-		key := fmt.Sprintf("limitranges(%s)", namespace)
-
-		limitrangeLister, found := c.listers[key]
+		return configmapLister.ConfigMaps(namespace).Get(name)
+	}
 
-		if !found {
-			limitrangeInformer := c.informerFactory(namespace).Core().V1().LimitRanges()
+	return c.kubeClient.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
 
-			limitrangeLister = limitrangeInformer.Lister()
+func (c *Client) limitrangeLister(namespace, fieldSelector string) (corev1listers.LimitRangeLister, error) {
+	c.Lock()
+	defer c.Unlock()
 
-			c.listers[key] = limitrangeLister
+	key := fmt.Sprintf("limitranges(%s,%s)", namespace, fieldSelector)
 
-			go limitrangeInformer.Informer().Run(c.stopCh)
+	limitrangeLister, found := c.listers[key]
 
-			if synced := cache.WaitForCacheSync(c.stopCh, limitrangeInformer.Informer().HasSynced); !synced {
-				return nil, errors.New("limitrange cache sync failed")
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.CoreV1().LimitRanges(namespace).List(context.TODO(), options); err != nil {
+				return nil, err
 			}
 		}
 
+		limitrangeInformer := c.informerFactory(namespace, fieldSelector).Core().V1().LimitRanges()
+
+		limitrangeHandler := c.eventHandler("limitranges")
+
+		limitrangeInformer.Informer().AddEventHandler(limitrangeHandler)
+
+		limitrangeLister = limitrangeInformer.Lister()
+
+		c.listers[key] = limitrangeLister
+
+		go limitrangeInformer.Informer().Run(c.stopCh)
+
+		if synced := cache.WaitForCacheSync(c.stopCh, limitrangeInformer.Informer().HasSynced); !synced {
+			return nil, errors.New("limitrange cache sync failed")
+		}
+
+		limitrangeHandler.informerSynced(limitrangeInformer.Informer().GetStore())
+	}
+
+	return limitrangeLister.(corev1listers.LimitRangeLister), nil
+}
+
+func (c *Client) LimitRanges(namespace, selector, fieldSelector string) ([]corev1.LimitRange, error) {
+	glog.V(4).Infof("fetching limitranges, namespace: %q, selector: %q, field selector: %q", namespace, selector, fieldSelector)
+
+	var limitranges []corev1.LimitRange
+
+	if c.useInformers {
+		limitrangeLister, err := c.limitrangeLister(namespace, fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+
 		s, err := labels.Parse(selector)
 		if err != nil {
 			return nil, err
 		}
 
-		es, err := limitrangeLister.(v1.LimitRangeLister).LimitRanges(namespace).List(s)
+		es, err := limitrangeLister.LimitRanges(namespace).List(s)
 		if err != nil {
 			return nil, err
 		}
@@ -603,7 +997,7 @@ func (c *Client) LimitRanges(namespace, selector string) ([]corev1.LimitRange, e
 			limitranges = append(limitranges, *e)
 		}
 	} else {
-		options := metav1.ListOptions{LabelSelector: selector}
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
 
 		limitrangeList, err := c.kubeClient.CoreV1().LimitRanges(namespace).List(context.TODO(), options)
 		if err != nil {
@@ -615,45 +1009,86 @@ func (c *Client) LimitRanges(namespace, selector string) ([]corev1.LimitRange, e
 
 	// Make list order stable
 	sort.Slice(limitranges, func(i, j int) bool {
+		if limitranges[i].Namespace != limitranges[j].Namespace {
+			return limitranges[i].Namespace < limitranges[j].Namespace
+		}
 		return limitranges[i].Name < limitranges[j].Name
 	})
 
 	return limitranges, nil
 }
 
-func (c *Client) PersistentVolumes(selector string) ([]corev1.PersistentVolume, error) {
-	glog.V(4).Infof("fetching persistentvolumes, selector: %q", selector)
-
-	var persistentvolumes []corev1.PersistentVolume
+func (c *Client) LimitRange(namespace, name string) (*corev1.LimitRange, error) {
+	glog.V(4).Infof("fetching limitrange, namespace: %q, name: %q", namespace, name)
 
 	if c.useInformers {
-		c.Lock()
-		defer c.Unlock()
-
-		key := fmt.Sprintf("persistentvolumes()")
+		limitrangeLister, err := c.limitrangeLister(namespace, "")
+		if err != nil {
+			return nil, err
+		}
 
-		persistentvolumeLister, found := c.listers[key]
+		return limitrangeLister.LimitRanges(namespace).Get(name)
+	}
 
-		if !found {
-			persistentvolumeInformer := c.informerFactory("").Core().V1().PersistentVolumes()
+	return c.kubeClient.CoreV1().LimitRanges(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
 
-			persistentvolumeLister = persistentvolumeInformer.Lister()
+func (c *Client) persistentvolumeLister(fieldSelector string) (corev1listers.PersistentVolumeLister, error) {
+	c.Lock()
+	defer c.Unlock()
 
-			c.listers[key] = persistentvolumeLister
+	key := fmt.Sprintf("persistentvolumes(%s)", fieldSelector)
 
-			go persistentvolumeInformer.Informer().Run(c.stopCh)
+	persistentvolumeLister, found := c.listers[key]
 
-			if synced := cache.WaitForCacheSync(c.stopCh, persistentvolumeInformer.Informer().HasSynced); !synced {
-				return nil, errors.New("persistentvolume cache sync failed")
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.CoreV1().PersistentVolumes().List(context.TODO(), options); err != nil {
+				return nil, err
 			}
 		}
 
+		persistentvolumeInformer := c.informerFactory("", fieldSelector).Core().V1().PersistentVolumes()
+
+		persistentvolumeHandler := c.eventHandler("persistentvolumes")
+
+		persistentvolumeInformer.Informer().AddEventHandler(persistentvolumeHandler)
+
+		persistentvolumeLister = persistentvolumeInformer.Lister()
+
+		c.listers[key] = persistentvolumeLister
+
+		go persistentvolumeInformer.Informer().Run(c.stopCh)
+
+		if synced := cache.WaitForCacheSync(c.stopCh, persistentvolumeInformer.Informer().HasSynced); !synced {
+			return nil, errors.New("persistentvolume cache sync failed")
+		}
+
+		persistentvolumeHandler.informerSynced(persistentvolumeInformer.Informer().GetStore())
+	}
+
+	return persistentvolumeLister.(corev1listers.PersistentVolumeLister), nil
+}
+
+func (c *Client) PersistentVolumes(selector, fieldSelector string) ([]corev1.PersistentVolume, error) {
+	glog.V(4).Infof("fetching persistentvolumes, selector: %q, field selector: %q", selector, fieldSelector)
+
+	var persistentvolumes []corev1.PersistentVolume
+
+	if c.useInformers {
+		persistentvolumeLister, err := c.persistentvolumeLister(fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+
 		s, err := labels.Parse(selector)
 		if err != nil {
 			return nil, err
 		}
 
-		es, err := persistentvolumeLister.(v1.PersistentVolumeLister).List(s)
+		es, err := persistentvolumeLister.List(s)
 		if err != nil {
 			return nil, err
 		}
@@ -662,7 +1097,7 @@ func (c *Client) PersistentVolumes(selector string) ([]corev1.PersistentVolume,
 			persistentvolumes = append(persistentvolumes, *e)
 		}
 	} else {
-		options := metav1.ListOptions{LabelSelector: selector}
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
 
 		persistentvolumeList, err := c.kubeClient.CoreV1().PersistentVolumes().List(context.TODO(), options)
 		if err != nil {
@@ -674,45 +1109,86 @@ func (c *Client) PersistentVolumes(selector string) ([]corev1.PersistentVolume,
 
 	// Make list order stable
 	sort.Slice(persistentvolumes, func(i, j int) bool {
+		if persistentvolumes[i].Namespace != persistentvolumes[j].Namespace {
+			return persistentvolumes[i].Namespace < persistentvolumes[j].Namespace
+		}
 		return persistentvolumes[i].Name < persistentvolumes[j].Name
 	})
 
 	return persistentvolumes, nil
 }
 
-func (c *Client) PersistentVolumeClaims(namespace, selector string) ([]corev1.PersistentVolumeClaim, error) {
-	glog.V(4).Infof("fetching persistentvolumeclaims, namespace: %q, selector: %q", namespace, selector)
-
-	var persistentvolumeclaims []corev1.PersistentVolumeClaim
+func (c *Client) PersistentVolume(name string) (*corev1.PersistentVolume, error) {
+	glog.V(4).Infof("fetching persistentvolume, name: %q", name)
 
 	if c.useInformers {
-		c.Lock()
-		defer c.Unlock()
-
-		key := fmt.Sprintf("persistentvolumeclaims(%s)", namespace)
+		persistentvolumeLister, err := c.persistentvolumeLister("")
+		if err != nil {
+			return nil, err
+		}
 
-		persistentvolumeclaimLister, found := c.listers[key]
+		return persistentvolumeLister.Get(name)
+	}
 
-		if !found {
-			persistentvolumeclaimInformer := c.informerFactory(namespace).Core().V1().PersistentVolumeClaims()
+	return c.kubeClient.CoreV1().PersistentVolumes().Get(context.TODO(), name, metav1.GetOptions{})
+}
 
-			persistentvolumeclaimLister = persistentvolumeclaimInformer.Lister()
+func (c *Client) persistentvolumeclaimLister(namespace, fieldSelector string) (corev1listers.PersistentVolumeClaimLister, error) {
+	c.Lock()
+	defer c.Unlock()
 
-			c.listers[key] = persistentvolumeclaimLister
+	key := fmt.Sprintf("persistentvolumeclaims(%s,%s)", namespace, fieldSelector)
 
-			go persistentvolumeclaimInformer.Informer().Run(c.stopCh)
+	persistentvolumeclaimLister, found := c.listers[key]
 
-			if synced := cache.WaitForCacheSync(c.stopCh, persistentvolumeclaimInformer.Informer().HasSynced); !synced {
-				return nil, errors.New("persistentvolumeclaim cache sync failed")
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.CoreV1().PersistentVolumeClaims(namespace).List(context.TODO(), options); err != nil {
+				return nil, err
 			}
 		}
 
+		persistentvolumeclaimInformer := c.informerFactory(namespace, fieldSelector).Core().V1().PersistentVolumeClaims()
+
+		persistentvolumeclaimHandler := c.eventHandler("persistentvolumeclaims")
+
+		persistentvolumeclaimInformer.Informer().AddEventHandler(persistentvolumeclaimHandler)
+
+		persistentvolumeclaimLister = persistentvolumeclaimInformer.Lister()
+
+		c.listers[key] = persistentvolumeclaimLister
+
+		go persistentvolumeclaimInformer.Informer().Run(c.stopCh)
+
+		if synced := cache.WaitForCacheSync(c.stopCh, persistentvolumeclaimInformer.Informer().HasSynced); !synced {
+			return nil, errors.New("persistentvolumeclaim cache sync failed")
+		}
+
+		persistentvolumeclaimHandler.informerSynced(persistentvolumeclaimInformer.Informer().GetStore())
+	}
+
+	return persistentvolumeclaimLister.(corev1listers.PersistentVolumeClaimLister), nil
+}
+
+func (c *Client) PersistentVolumeClaims(namespace, selector, fieldSelector string) ([]corev1.PersistentVolumeClaim, error) {
+	glog.V(4).Infof("fetching persistentvolumeclaims, namespace: %q, selector: %q, field selector: %q", namespace, selector, fieldSelector)
+
+	var persistentvolumeclaims []corev1.PersistentVolumeClaim
+
+	if c.useInformers {
+		persistentvolumeclaimLister, err := c.persistentvolumeclaimLister(namespace, fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+
 		s, err := labels.Parse(selector)
 		if err != nil {
 			return nil, err
 		}
 
-		es, err := persistentvolumeclaimLister.(v1.PersistentVolumeClaimLister).PersistentVolumeClaims(namespace).List(s)
+		es, err := persistentvolumeclaimLister.PersistentVolumeClaims(namespace).List(s)
 		if err != nil {
 			return nil, err
 		}
@@ -721,7 +1197,7 @@ func (c *Client) PersistentVolumeClaims(namespace, selector string) ([]corev1.Pe
 			persistentvolumeclaims = append(persistentvolumeclaims, *e)
 		}
 	} else {
-		options := metav1.ListOptions{LabelSelector: selector}
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
 
 		persistentvolumeclaimList, err := c.kubeClient.CoreV1().PersistentVolumeClaims(namespace).List(context.TODO(), options)
 		if err != nil {
@@ -733,45 +1209,86 @@ func (c *Client) PersistentVolumeClaims(namespace, selector string) ([]corev1.Pe
 
 	// Make list order stable
 	sort.Slice(persistentvolumeclaims, func(i, j int) bool {
+		if persistentvolumeclaims[i].Namespace != persistentvolumeclaims[j].Namespace {
+			return persistentvolumeclaims[i].Namespace < persistentvolumeclaims[j].Namespace
+		}
 		return persistentvolumeclaims[i].Name < persistentvolumeclaims[j].Name
 	})
 
 	return persistentvolumeclaims, nil
 }
 
-func (c *Client) PodTemplates(namespace, selector string) ([]corev1.PodTemplate, error) {
-	glog.V(4).Infof("fetching podtemplates, namespace: %q, selector: %q", namespace, selector)
-
-	var podtemplates []corev1.PodTemplate
+func (c *Client) PersistentVolumeClaim(namespace, name string) (*corev1.PersistentVolumeClaim, error) {
+	glog.V(4).Infof("fetching persistentvolumeclaim, namespace: %q, name: %q", namespace, name)
 
 	if c.useInformers {
-		c.Lock()
-		defer c.Unlock()
-
-		key := fmt.Sprintf("podtemplates(%s)", namespace)
+		persistentvolumeclaimLister, err := c.persistentvolumeclaimLister(namespace, "")
+		if err != nil {
+			return nil, err
+		}
 
-		podtemplateLister, found := c.listers[key]
+		return persistentvolumeclaimLister.PersistentVolumeClaims(namespace).Get(name)
+	}
 
-		if !found {
-			podtemplateInformer := c.informerFactory(namespace).Core().V1().PodTemplates()
+	return c.kubeClient.CoreV1().PersistentVolumeClaims(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
 
-			podtemplateLister = podtemplateInformer.Lister()
+func (c *Client) podtemplateLister(namespace, fieldSelector string) (corev1listers.PodTemplateLister, error) {
+	c.Lock()
+	defer c.Unlock()
 
-			c.listers[key] = podtemplateLister
+	key := fmt.Sprintf("podtemplates(%s,%s)", namespace, fieldSelector)
 
-			go podtemplateInformer.Informer().Run(c.stopCh)
+	podtemplateLister, found := c.listers[key]
 
-			if synced := cache.WaitForCacheSync(c.stopCh, podtemplateInformer.Informer().HasSynced); !synced {
-				return nil, errors.New("podtemplate cache sync failed")
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.CoreV1().PodTemplates(namespace).List(context.TODO(), options); err != nil {
+				return nil, err
 			}
 		}
 
+		podtemplateInformer := c.informerFactory(namespace, fieldSelector).Core().V1().PodTemplates()
+
+		podtemplateHandler := c.eventHandler("podtemplates")
+
+		podtemplateInformer.Informer().AddEventHandler(podtemplateHandler)
+
+		podtemplateLister = podtemplateInformer.Lister()
+
+		c.listers[key] = podtemplateLister
+
+		go podtemplateInformer.Informer().Run(c.stopCh)
+
+		if synced := cache.WaitForCacheSync(c.stopCh, podtemplateInformer.Informer().HasSynced); !synced {
+			return nil, errors.New("podtemplate cache sync failed")
+		}
+
+		podtemplateHandler.informerSynced(podtemplateInformer.Informer().GetStore())
+	}
+
+	return podtemplateLister.(corev1listers.PodTemplateLister), nil
+}
+
+func (c *Client) PodTemplates(namespace, selector, fieldSelector string) ([]corev1.PodTemplate, error) {
+	glog.V(4).Infof("fetching podtemplates, namespace: %q, selector: %q, field selector: %q", namespace, selector, fieldSelector)
+
+	var podtemplates []corev1.PodTemplate
+
+	if c.useInformers {
+		podtemplateLister, err := c.podtemplateLister(namespace, fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+
 		s, err := labels.Parse(selector)
 		if err != nil {
 			return nil, err
 		}
 
-		es, err := podtemplateLister.(v1.PodTemplateLister).PodTemplates(namespace).List(s)
+		es, err := podtemplateLister.PodTemplates(namespace).List(s)
 		if err != nil {
 			return nil, err
 		}
@@ -780,7 +1297,7 @@ func (c *Client) PodTemplates(namespace, selector string) ([]corev1.PodTemplate,
 			podtemplates = append(podtemplates, *e)
 		}
 	} else {
-		options := metav1.ListOptions{LabelSelector: selector}
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
 
 		podtemplateList, err := c.kubeClient.CoreV1().PodTemplates(namespace).List(context.TODO(), options)
 		if err != nil {
@@ -792,45 +1309,86 @@ func (c *Client) PodTemplates(namespace, selector string) ([]corev1.PodTemplate,
 
 	// Make list order stable
 	sort.Slice(podtemplates, func(i, j int) bool {
+		if podtemplates[i].Namespace != podtemplates[j].Namespace {
+			return podtemplates[i].Namespace < podtemplates[j].Namespace
+		}
 		return podtemplates[i].Name < podtemplates[j].Name
 	})
 
 	return podtemplates, nil
 }
 
-func (c *Client) ResourceQuotas(namespace, selector string) ([]corev1.ResourceQuota, error) {
-	glog.V(4).Infof("fetching resourcequotas, namespace: %q, selector: %q", namespace, selector)
-
-	var resourcequotas []corev1.ResourceQuota
+func (c *Client) PodTemplate(namespace, name string) (*corev1.PodTemplate, error) {
+	glog.V(4).Infof("fetching podtemplate, namespace: %q, name: %q", namespace, name)
 
 	if c.useInformers {
-		c.Lock()
-		defer c.Unlock()
-
-		key := fmt.Sprintf("resourcequotas(%s)", namespace)
+		podtemplateLister, err := c.podtemplateLister(namespace, "")
+		if err != nil {
+			return nil, err
+		}
 
-		resourcequotaLister, found := c.listers[key]
+		return podtemplateLister.PodTemplates(namespace).Get(name)
+	}
 
-		if !found {
-			resourcequotaInformer := c.informerFactory(namespace).Core().V1().ResourceQuotas()
+	return c.kubeClient.CoreV1().PodTemplates(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
 
-			resourcequotaLister = resourcequotaInformer.Lister()
+func (c *Client) resourcequotaLister(namespace, fieldSelector string) (corev1listers.ResourceQuotaLister, error) {
+	c.Lock()
+	defer c.Unlock()
 
-			c.listers[key] = resourcequotaLister
+	key := fmt.Sprintf("resourcequotas(%s,%s)", namespace, fieldSelector)
 
-			go resourcequotaInformer.Informer().Run(c.stopCh)
+	resourcequotaLister, found := c.listers[key]
 
-			if synced := cache.WaitForCacheSync(c.stopCh, resourcequotaInformer.Informer().HasSynced); !synced {
-				return nil, errors.New("resourcequota cache sync failed")
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.CoreV1().ResourceQuotas(namespace).List(context.TODO(), options); err != nil {
+				return nil, err
 			}
 		}
 
+		resourcequotaInformer := c.informerFactory(namespace, fieldSelector).Core().V1().ResourceQuotas()
+
+		resourcequotaHandler := c.eventHandler("resourcequotas")
+
+		resourcequotaInformer.Informer().AddEventHandler(resourcequotaHandler)
+
+		resourcequotaLister = resourcequotaInformer.Lister()
+
+		c.listers[key] = resourcequotaLister
+
+		go resourcequotaInformer.Informer().Run(c.stopCh)
+
+		if synced := cache.WaitForCacheSync(c.stopCh, resourcequotaInformer.Informer().HasSynced); !synced {
+			return nil, errors.New("resourcequota cache sync failed")
+		}
+
+		resourcequotaHandler.informerSynced(resourcequotaInformer.Informer().GetStore())
+	}
+
+	return resourcequotaLister.(corev1listers.ResourceQuotaLister), nil
+}
+
+func (c *Client) ResourceQuotas(namespace, selector, fieldSelector string) ([]corev1.ResourceQuota, error) {
+	glog.V(4).Infof("fetching resourcequotas, namespace: %q, selector: %q, field selector: %q", namespace, selector, fieldSelector)
+
+	var resourcequotas []corev1.ResourceQuota
+
+	if c.useInformers {
+		resourcequotaLister, err := c.resourcequotaLister(namespace, fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+
 		s, err := labels.Parse(selector)
 		if err != nil {
 			return nil, err
 		}
 
-		es, err := resourcequotaLister.(v1.ResourceQuotaLister).ResourceQuotas(namespace).List(s)
+		es, err := resourcequotaLister.ResourceQuotas(namespace).List(s)
 		if err != nil {
 			return nil, err
 		}
@@ -839,7 +1397,7 @@ func (c *Client) ResourceQuotas(namespace, selector string) ([]corev1.ResourceQu
 			resourcequotas = append(resourcequotas, *e)
 		}
 	} else {
-		options := metav1.ListOptions{LabelSelector: selector}
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
 
 		resourcequotaList, err := c.kubeClient.CoreV1().ResourceQuotas(namespace).List(context.TODO(), options)
 		if err != nil {
@@ -851,45 +1409,86 @@ func (c *Client) ResourceQuotas(namespace, selector string) ([]corev1.ResourceQu
 
 	// Make list order stable
 	sort.Slice(resourcequotas, func(i, j int) bool {
+		if resourcequotas[i].Namespace != resourcequotas[j].Namespace {
+			return resourcequotas[i].Namespace < resourcequotas[j].Namespace
+		}
 		return resourcequotas[i].Name < resourcequotas[j].Name
 	})
 
 	return resourcequotas, nil
 }
 
-func (c *Client) Secrets(namespace, selector string) ([]corev1.Secret, error) {
-	glog.V(4).Infof("fetching secrets, namespace: %q, selector: %q", namespace, selector)
-
-	var secrets []corev1.Secret
+func (c *Client) ResourceQuota(namespace, name string) (*corev1.ResourceQuota, error) {
+	glog.V(4).Infof("fetching resourcequota, namespace: %q, name: %q", namespace, name)
 
 	if c.useInformers {
-		c.Lock()
-		defer c.Unlock()
-
-		key := fmt.Sprintf("secrets(%s)", namespace)
+		resourcequotaLister, err := c.resourcequotaLister(namespace, "")
+		if err != nil {
+			return nil, err
+		}
 
-		secretLister, found := c.listers[key]
+		return resourcequotaLister.ResourceQuotas(namespace).Get(name)
+	}
 
-		if !found {
-			secretInformer := c.informerFactory(namespace).Core().V1().Secrets()
+	return c.kubeClient.CoreV1().ResourceQuotas(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
 
-			secretLister = secretInformer.Lister()
+func (c *Client) secretLister(namespace, fieldSelector string) (corev1listers.SecretLister, error) {
+	c.Lock()
+	defer c.Unlock()
 
-			c.listers[key] = secretLister
+	key := fmt.Sprintf("secrets(%s,%s)", namespace, fieldSelector)
 
-			go secretInformer.Informer().Run(c.stopCh)
+	secretLister, found := c.listers[key]
 
-			if synced := cache.WaitForCacheSync(c.stopCh, secretInformer.Informer().HasSynced); !synced {
-				return nil, errors.New("secret cache sync failed")
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.CoreV1().Secrets(namespace).List(context.TODO(), options); err != nil {
+				return nil, err
 			}
 		}
 
+		secretInformer := c.informerFactory(namespace, fieldSelector).Core().V1().Secrets()
+
+		secretHandler := c.eventHandler("secrets")
+
+		secretInformer.Informer().AddEventHandler(secretHandler)
+
+		secretLister = secretInformer.Lister()
+
+		c.listers[key] = secretLister
+
+		go secretInformer.Informer().Run(c.stopCh)
+
+		if synced := cache.WaitForCacheSync(c.stopCh, secretInformer.Informer().HasSynced); !synced {
+			return nil, errors.New("secret cache sync failed")
+		}
+
+		secretHandler.informerSynced(secretInformer.Informer().GetStore())
+	}
+
+	return secretLister.(corev1listers.SecretLister), nil
+}
+
+func (c *Client) Secrets(namespace, selector, fieldSelector string) ([]corev1.Secret, error) {
+	glog.V(4).Infof("fetching secrets, namespace: %q, selector: %q, field selector: %q", namespace, selector, fieldSelector)
+
+	var secrets []corev1.Secret
+
+	if c.useInformers {
+		secretLister, err := c.secretLister(namespace, fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+
 		s, err := labels.Parse(selector)
 		if err != nil {
 			return nil, err
 		}
 
-		es, err := secretLister.(v1.SecretLister).Secrets(namespace).List(s)
+		es, err := secretLister.Secrets(namespace).List(s)
 		if err != nil {
 			return nil, err
 		}
@@ -898,7 +1497,7 @@ func (c *Client) Secrets(namespace, selector string) ([]corev1.Secret, error) {
 			secrets = append(secrets, *e)
 		}
 	} else {
-		options := metav1.ListOptions{LabelSelector: selector}
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
 
 		secretList, err := c.kubeClient.CoreV1().Secrets(namespace).List(context.TODO(), options)
 		if err != nil {
@@ -910,45 +1509,86 @@ func (c *Client) Secrets(namespace, selector string) ([]corev1.Secret, error) {
 
 	// Make list order stable
 	sort.Slice(secrets, func(i, j int) bool {
+		if secrets[i].Namespace != secrets[j].Namespace {
+			return secrets[i].Namespace < secrets[j].Namespace
+		}
 		return secrets[i].Name < secrets[j].Name
 	})
 
 	return secrets, nil
 }
 
-func (c *Client) ServiceAccounts(namespace, selector string) ([]corev1.ServiceAccount, error) {
-	glog.V(4).Infof("fetching serviceaccounts, namespace: %q, selector: %q", namespace, selector)
-
-	var serviceaccounts []corev1.ServiceAccount
+func (c *Client) Secret(namespace, name string) (*corev1.Secret, error) {
+	glog.V(4).Infof("fetching secret, namespace: %q, name: %q", namespace, name)
 
 	if c.useInformers {
-		c.Lock()
-		defer c.Unlock()
-
-		key := fmt.Sprintf("serviceaccounts(%s)", namespace)
+		secretLister, err := c.secretLister(namespace, "")
+		if err != nil {
+			return nil, err
+		}
 
-		serviceaccountLister, found := c.listers[key]
+		return secretLister.Secrets(namespace).Get(name)
+	}
 
-		if !found {
-			serviceaccountInformer := c.informerFactory(namespace).Core().V1().ServiceAccounts()
+	return c.kubeClient.CoreV1().Secrets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
 
-			serviceaccountLister = serviceaccountInformer.Lister()
+func (c *Client) serviceaccountLister(namespace, fieldSelector string) (corev1listers.ServiceAccountLister, error) {
+	c.Lock()
+	defer c.Unlock()
 
-			c.listers[key] = serviceaccountLister
+	key := fmt.Sprintf("serviceaccounts(%s,%s)", namespace, fieldSelector)
 
-			go serviceaccountInformer.Informer().Run(c.stopCh)
+	serviceaccountLister, found := c.listers[key]
 
-			if synced := cache.WaitForCacheSync(c.stopCh, serviceaccountInformer.Informer().HasSynced); !synced {
-				return nil, errors.New("serviceaccount cache sync failed")
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.CoreV1().ServiceAccounts(namespace).List(context.TODO(), options); err != nil {
+				return nil, err
 			}
 		}
 
+		serviceaccountInformer := c.informerFactory(namespace, fieldSelector).Core().V1().ServiceAccounts()
+
+		serviceaccountHandler := c.eventHandler("serviceaccounts")
+
+		serviceaccountInformer.Informer().AddEventHandler(serviceaccountHandler)
+
+		serviceaccountLister = serviceaccountInformer.Lister()
+
+		c.listers[key] = serviceaccountLister
+
+		go serviceaccountInformer.Informer().Run(c.stopCh)
+
+		if synced := cache.WaitForCacheSync(c.stopCh, serviceaccountInformer.Informer().HasSynced); !synced {
+			return nil, errors.New("serviceaccount cache sync failed")
+		}
+
+		serviceaccountHandler.informerSynced(serviceaccountInformer.Informer().GetStore())
+	}
+
+	return serviceaccountLister.(corev1listers.ServiceAccountLister), nil
+}
+
+func (c *Client) ServiceAccounts(namespace, selector, fieldSelector string) ([]corev1.ServiceAccount, error) {
+	glog.V(4).Infof("fetching serviceaccounts, namespace: %q, selector: %q, field selector: %q", namespace, selector, fieldSelector)
+
+	var serviceaccounts []corev1.ServiceAccount
+
+	if c.useInformers {
+		serviceaccountLister, err := c.serviceaccountLister(namespace, fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+
 		s, err := labels.Parse(selector)
 		if err != nil {
 			return nil, err
 		}
 
-		es, err := serviceaccountLister.(v1.ServiceAccountLister).ServiceAccounts(namespace).List(s)
+		es, err := serviceaccountLister.ServiceAccounts(namespace).List(s)
 		if err != nil {
 			return nil, err
 		}
@@ -957,7 +1597,7 @@ func (c *Client) ServiceAccounts(namespace, selector string) ([]corev1.ServiceAc
 			serviceaccounts = append(serviceaccounts, *e)
 		}
 	} else {
-		options := metav1.ListOptions{LabelSelector: selector}
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
 
 		serviceaccountList, err := c.kubeClient.CoreV1().ServiceAccounts(namespace).List(context.TODO(), options)
 		if err != nil {
@@ -969,8 +1609,426 @@ func (c *Client) ServiceAccounts(namespace, selector string) ([]corev1.ServiceAc
 
 	// Make list order stable
 	sort.Slice(serviceaccounts, func(i, j int) bool {
+		if serviceaccounts[i].Namespace != serviceaccounts[j].Namespace {
+			return serviceaccounts[i].Namespace < serviceaccounts[j].Namespace
+		}
 		return serviceaccounts[i].Name < serviceaccounts[j].Name
 	})
 
 	return serviceaccounts, nil
 }
+
+func (c *Client) ServiceAccount(namespace, name string) (*corev1.ServiceAccount, error) {
+	glog.V(4).Infof("fetching serviceaccount, namespace: %q, name: %q", namespace, name)
+
+	if c.useInformers {
+		serviceaccountLister, err := c.serviceaccountLister(namespace, "")
+		if err != nil {
+			return nil, err
+		}
+
+		return serviceaccountLister.ServiceAccounts(namespace).Get(name)
+	}
+
+	return c.kubeClient.CoreV1().ServiceAccounts(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+func (c *Client) deploymentLister(namespace, fieldSelector string) (appsv1listers.DeploymentLister, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	key := fmt.Sprintf("deployments(%s,%s)", namespace, fieldSelector)
+
+	deploymentLister, found := c.listers[key]
+
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.AppsV1().Deployments(namespace).List(context.TODO(), options); err != nil {
+				return nil, err
+			}
+		}
+
+		deploymentInformer := c.informerFactory(namespace, fieldSelector).Apps().V1().Deployments()
+
+		deploymentHandler := c.eventHandler("deployments")
+
+		deploymentInformer.Informer().AddEventHandler(deploymentHandler)
+
+		deploymentLister = deploymentInformer.Lister()
+
+		c.listers[key] = deploymentLister
+
+		go deploymentInformer.Informer().Run(c.stopCh)
+
+		if synced := cache.WaitForCacheSync(c.stopCh, deploymentInformer.Informer().HasSynced); !synced {
+			return nil, errors.New("deployment cache sync failed")
+		}
+
+		deploymentHandler.informerSynced(deploymentInformer.Informer().GetStore())
+	}
+
+	return deploymentLister.(appsv1listers.DeploymentLister), nil
+}
+
+func (c *Client) Deployments(namespace, selector, fieldSelector string) ([]appsv1.Deployment, error) {
+	glog.V(4).Infof("fetching deployments, namespace: %q, selector: %q, field selector: %q", namespace, selector, fieldSelector)
+
+	var deployments []appsv1.Deployment
+
+	if c.useInformers {
+		deploymentLister, err := c.deploymentLister(namespace, fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+
+		s, err := labels.Parse(selector)
+		if err != nil {
+			return nil, err
+		}
+
+		es, err := deploymentLister.Deployments(namespace).List(s)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range es {
+			deployments = append(deployments, *e)
+		}
+	} else {
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
+
+		deploymentList, err := c.kubeClient.AppsV1().Deployments(namespace).List(context.TODO(), options)
+		if err != nil {
+			return nil, err
+		}
+
+		deployments = deploymentList.Items
+	}
+
+	// Make list order stable
+	sort.Slice(deployments, func(i, j int) bool {
+		if deployments[i].Namespace != deployments[j].Namespace {
+			return deployments[i].Namespace < deployments[j].Namespace
+		}
+		return deployments[i].Name < deployments[j].Name
+	})
+
+	return deployments, nil
+}
+
+func (c *Client) Deployment(namespace, name string) (*appsv1.Deployment, error) {
+	glog.V(4).Infof("fetching deployment, namespace: %q, name: %q", namespace, name)
+
+	if c.useInformers {
+		deploymentLister, err := c.deploymentLister(namespace, "")
+		if err != nil {
+			return nil, err
+		}
+
+		return deploymentLister.Deployments(namespace).Get(name)
+	}
+
+	return c.kubeClient.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+func (c *Client) statefulsetLister(namespace, fieldSelector string) (appsv1listers.StatefulSetLister, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	key := fmt.Sprintf("statefulsets(%s,%s)", namespace, fieldSelector)
+
+	statefulsetLister, found := c.listers[key]
+
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.AppsV1().StatefulSets(namespace).List(context.TODO(), options); err != nil {
+				return nil, err
+			}
+		}
+
+		statefulsetInformer := c.informerFactory(namespace, fieldSelector).Apps().V1().StatefulSets()
+
+		statefulsetHandler := c.eventHandler("statefulsets")
+
+		statefulsetInformer.Informer().AddEventHandler(statefulsetHandler)
+
+		statefulsetLister = statefulsetInformer.Lister()
+
+		c.listers[key] = statefulsetLister
+
+		go statefulsetInformer.Informer().Run(c.stopCh)
+
+		if synced := cache.WaitForCacheSync(c.stopCh, statefulsetInformer.Informer().HasSynced); !synced {
+			return nil, errors.New("statefulset cache sync failed")
+		}
+
+		statefulsetHandler.informerSynced(statefulsetInformer.Informer().GetStore())
+	}
+
+	return statefulsetLister.(appsv1listers.StatefulSetLister), nil
+}
+
+func (c *Client) StatefulSets(namespace, selector, fieldSelector string) ([]appsv1.StatefulSet, error) {
+	glog.V(4).Infof("fetching statefulsets, namespace: %q, selector: %q, field selector: %q", namespace, selector, fieldSelector)
+
+	var statefulsets []appsv1.StatefulSet
+
+	if c.useInformers {
+		statefulsetLister, err := c.statefulsetLister(namespace, fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+
+		s, err := labels.Parse(selector)
+		if err != nil {
+			return nil, err
+		}
+
+		es, err := statefulsetLister.StatefulSets(namespace).List(s)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range es {
+			statefulsets = append(statefulsets, *e)
+		}
+	} else {
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
+
+		statefulsetList, err := c.kubeClient.AppsV1().StatefulSets(namespace).List(context.TODO(), options)
+		if err != nil {
+			return nil, err
+		}
+
+		statefulsets = statefulsetList.Items
+	}
+
+	// Make list order stable
+	sort.Slice(statefulsets, func(i, j int) bool {
+		if statefulsets[i].Namespace != statefulsets[j].Namespace {
+			return statefulsets[i].Namespace < statefulsets[j].Namespace
+		}
+		return statefulsets[i].Name < statefulsets[j].Name
+	})
+
+	return statefulsets, nil
+}
+
+func (c *Client) StatefulSet(namespace, name string) (*appsv1.StatefulSet, error) {
+	glog.V(4).Infof("fetching statefulset, namespace: %q, name: %q", namespace, name)
+
+	if c.useInformers {
+		statefulsetLister, err := c.statefulsetLister(namespace, "")
+		if err != nil {
+			return nil, err
+		}
+
+		return statefulsetLister.StatefulSets(namespace).Get(name)
+	}
+
+	return c.kubeClient.AppsV1().StatefulSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+func (c *Client) daemonsetLister(namespace, fieldSelector string) (appsv1listers.DaemonSetLister, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	key := fmt.Sprintf("daemonsets(%s,%s)", namespace, fieldSelector)
+
+	daemonsetLister, found := c.listers[key]
+
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.AppsV1().DaemonSets(namespace).List(context.TODO(), options); err != nil {
+				return nil, err
+			}
+		}
+
+		daemonsetInformer := c.informerFactory(namespace, fieldSelector).Apps().V1().DaemonSets()
+
+		daemonsetHandler := c.eventHandler("daemonsets")
+
+		daemonsetInformer.Informer().AddEventHandler(daemonsetHandler)
+
+		daemonsetLister = daemonsetInformer.Lister()
+
+		c.listers[key] = daemonsetLister
+
+		go daemonsetInformer.Informer().Run(c.stopCh)
+
+		if synced := cache.WaitForCacheSync(c.stopCh, daemonsetInformer.Informer().HasSynced); !synced {
+			return nil, errors.New("daemonset cache sync failed")
+		}
+
+		daemonsetHandler.informerSynced(daemonsetInformer.Informer().GetStore())
+	}
+
+	return daemonsetLister.(appsv1listers.DaemonSetLister), nil
+}
+
+func (c *Client) DaemonSets(namespace, selector, fieldSelector string) ([]appsv1.DaemonSet, error) {
+	glog.V(4).Infof("fetching daemonsets, namespace: %q, selector: %q, field selector: %q", namespace, selector, fieldSelector)
+
+	var daemonsets []appsv1.DaemonSet
+
+	if c.useInformers {
+		daemonsetLister, err := c.daemonsetLister(namespace, fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+
+		s, err := labels.Parse(selector)
+		if err != nil {
+			return nil, err
+		}
+
+		es, err := daemonsetLister.DaemonSets(namespace).List(s)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range es {
+			daemonsets = append(daemonsets, *e)
+		}
+	} else {
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
+
+		daemonsetList, err := c.kubeClient.AppsV1().DaemonSets(namespace).List(context.TODO(), options)
+		if err != nil {
+			return nil, err
+		}
+
+		daemonsets = daemonsetList.Items
+	}
+
+	// Make list order stable
+	sort.Slice(daemonsets, func(i, j int) bool {
+		if daemonsets[i].Namespace != daemonsets[j].Namespace {
+			return daemonsets[i].Namespace < daemonsets[j].Namespace
+		}
+		return daemonsets[i].Name < daemonsets[j].Name
+	})
+
+	return daemonsets, nil
+}
+
+func (c *Client) DaemonSet(namespace, name string) (*appsv1.DaemonSet, error) {
+	glog.V(4).Infof("fetching daemonset, namespace: %q, name: %q", namespace, name)
+
+	if c.useInformers {
+		daemonsetLister, err := c.daemonsetLister(namespace, "")
+		if err != nil {
+			return nil, err
+		}
+
+		return daemonsetLister.DaemonSets(namespace).Get(name)
+	}
+
+	return c.kubeClient.AppsV1().DaemonSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+func (c *Client) replicasetLister(namespace, fieldSelector string) (appsv1listers.ReplicaSetLister, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	key := fmt.Sprintf("replicasets(%s,%s)", namespace, fieldSelector)
+
+	replicasetLister, found := c.listers[key]
+
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.AppsV1().ReplicaSets(namespace).List(context.TODO(), options); err != nil {
+				return nil, err
+			}
+		}
+
+		replicasetInformer := c.informerFactory(namespace, fieldSelector).Apps().V1().ReplicaSets()
+
+		replicasetHandler := c.eventHandler("replicasets")
+
+		replicasetInformer.Informer().AddEventHandler(replicasetHandler)
+
+		replicasetLister = replicasetInformer.Lister()
+
+		c.listers[key] = replicasetLister
+
+		go replicasetInformer.Informer().Run(c.stopCh)
+
+		if synced := cache.WaitForCacheSync(c.stopCh, replicasetInformer.Informer().HasSynced); !synced {
+			return nil, errors.New("replicaset cache sync failed")
+		}
+
+		replicasetHandler.informerSynced(replicasetInformer.Informer().GetStore())
+	}
+
+	return replicasetLister.(appsv1listers.ReplicaSetLister), nil
+}
+
+func (c *Client) ReplicaSets(namespace, selector, fieldSelector string) ([]appsv1.ReplicaSet, error) {
+	glog.V(4).Infof("fetching replicasets, namespace: %q, selector: %q, field selector: %q", namespace, selector, fieldSelector)
+
+	var replicasets []appsv1.ReplicaSet
+
+	if c.useInformers {
+		replicasetLister, err := c.replicasetLister(namespace, fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+
+		s, err := labels.Parse(selector)
+		if err != nil {
+			return nil, err
+		}
+
+		es, err := replicasetLister.ReplicaSets(namespace).List(s)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range es {
+			replicasets = append(replicasets, *e)
+		}
+	} else {
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
+
+		replicasetList, err := c.kubeClient.AppsV1().ReplicaSets(namespace).List(context.TODO(), options)
+		if err != nil {
+			return nil, err
+		}
+
+		replicasets = replicasetList.Items
+	}
+
+	// Make list order stable
+	sort.Slice(replicasets, func(i, j int) bool {
+		if replicasets[i].Namespace != replicasets[j].Namespace {
+			return replicasets[i].Namespace < replicasets[j].Namespace
+		}
+		return replicasets[i].Name < replicasets[j].Name
+	})
+
+	return replicasets, nil
+}
+
+func (c *Client) ReplicaSet(namespace, name string) (*appsv1.ReplicaSet, error) {
+	glog.V(4).Infof("fetching replicaset, namespace: %q, name: %q", namespace, name)
+
+	if c.useInformers {
+		replicasetLister, err := c.replicasetLister(namespace, "")
+		if err != nil {
+			return nil, err
+		}
+
+		return replicasetLister.ReplicaSets(namespace).Get(name)
+	}
+
+	return c.kubeClient.AppsV1().ReplicaSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
@@ -1,7 +1,19 @@
 package main
 
 import (
+	"context"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/kubernetes/pkg/controller/testutil"
 
 	"github.com/stretchr/testify/require"
@@ -26,22 +38,221 @@ func testClientGetPods(t *testing.T, useInformer bool) {
 	stopCh := make(chan struct{})
 	defer close(stopCh)
 
-	tc, err := newClient(fakeClient, stopCh, useInformer)
+	tc, err := newClient(fakeClient, nil, stopCh, useInformer)
 	require.NoError(t, err)
 
-	pods, err := tc.Pods("", "")
+	pods, err := tc.Pods("", "", "")
 	require.NoError(t, err)
 	require.Len(t, pods, 1)
 	require.Equal(t, "pod1", pods[0].Name)
 	require.Equal(t, "host1", pods[0].Spec.NodeName)
 
-	pods, err = tc.Pods("", "name=unknown")
+	pods, err = tc.Pods("", "name=unknown", "")
 	require.NoError(t, err)
 	require.Empty(t, pods)
 
-	pods, err = tc.Pods("", "name=pod1")
+	pods, err = tc.Pods("", "name=pod1", "")
 	require.NoError(t, err)
 	require.Len(t, pods, 1)
 	require.Equal(t, "pod1", pods[0].Name)
 	require.Equal(t, "host1", pods[0].Spec.NodeName)
 }
+
+func TestClientUpdates(t *testing.T) {
+	pod := testutil.NewPod("pod1", "host1")
+	fakeClient := fake.NewSimpleClientset(pod)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	tc, err := newClient(fakeClient, nil, stopCh, true)
+	require.NoError(t, err)
+
+	_, err = tc.Pods("", "", "")
+	require.NoError(t, err)
+
+	// Initially listed pods are not notified as added
+	select {
+	case <-tc.Updates():
+		t.Fatalf("unexpected update notification: %v", tc.Changes())
+	case <-time.After(200 * time.Millisecond):
+	}
+	require.Empty(t, tc.Changes())
+
+	_, err = fakeClient.CoreV1().Pods(metav1.NamespaceDefault).Create(context.TODO(), testutil.NewPod("pod2", "host1"), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	select {
+	case <-tc.Updates():
+	case <-time.After(5 * time.Second):
+		t.Fatal("no update notification received")
+	}
+	changes := tc.Changes()
+	require.Len(t, changes, 1)
+	require.Equal(t, "pod2", changes[0].name)
+}
+
+func TestClientGetDeploymentsDirectly(t *testing.T) {
+	testClientGetDeployments(t, false)
+}
+
+func TestClientGetDeploymentsUsingInformer(t *testing.T) {
+	testClientGetDeployments(t, true)
+}
+
+func testClientGetDeployments(t *testing.T, useInformer bool) {
+	replicas := int32(3)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "deployment1",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{"name": "deployment1"},
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+	}
+
+	fakeClient := fake.NewSimpleClientset(deployment)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	tc, err := newClient(fakeClient, nil, stopCh, useInformer)
+	require.NoError(t, err)
+
+	deployments, err := tc.Deployments(metav1.NamespaceDefault, "name=deployment1", "")
+	require.NoError(t, err)
+	require.Len(t, deployments, 1)
+	require.Equal(t, "deployment1", deployments[0].Name)
+	require.Equal(t, replicas, *deployments[0].Spec.Replicas)
+
+	deployments, err = tc.Deployments(metav1.NamespaceDefault, "name=unknown", "")
+	require.NoError(t, err)
+	require.Empty(t, deployments)
+}
+
+func TestClientGetResourcesDirectly(t *testing.T) {
+	testClientGetResources(t, false)
+}
+
+func TestClientGetResourcesUsingInformer(t *testing.T) {
+	testClientGetResources(t, true)
+}
+
+func newTestCertificate(name, namespace, secretName string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "cert-manager.io/v1",
+			"kind":       "Certificate",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+				"labels":    map[string]interface{}{"name": name},
+			},
+			"spec": map[string]interface{}{
+				"secretName": secretName,
+			},
+		},
+	}
+}
+
+func testClientGetResources(t *testing.T, useInformer bool) {
+	fakeDynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(),
+		newTestCertificate("cert2", metav1.NamespaceDefault, "secret2"),
+		newTestCertificate("cert1", metav1.NamespaceDefault, "secret1"))
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	tc, err := newClient(fake.NewSimpleClientset(), fakeDynamicClient, stopCh, useInformer)
+	require.NoError(t, err)
+
+	gvr := schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+
+	certs, err := tc.Resources(gvr, metav1.NamespaceDefault, "", "")
+	require.NoError(t, err)
+	require.Len(t, certs, 2)
+	require.Equal(t, "cert1", certs[0].GetName())
+	require.Equal(t, "secret1", certs[0].Object["spec"].(map[string]interface{})["secretName"])
+	require.Equal(t, "cert2", certs[1].GetName())
+
+	certs, err = tc.Resources(gvr, metav1.NamespaceDefault, "name=cert2", "")
+	require.NoError(t, err)
+	require.Len(t, certs, 1)
+	require.Equal(t, "cert2", certs[0].GetName())
+
+	certs, err = tc.Resources(gvr, metav1.NamespaceDefault, "name=unknown", "")
+	require.NoError(t, err)
+	require.Empty(t, certs)
+}
+
+func TestClientGetPodsWithFieldSelectorDirectly(t *testing.T) {
+	testClientGetPodsWithFieldSelector(t, false)
+}
+
+func TestClientGetPodsWithFieldSelectorUsingInformer(t *testing.T) {
+	testClientGetPodsWithFieldSelector(t, true)
+}
+
+func testClientGetPodsWithFieldSelector(t *testing.T, useInformer bool) {
+	fakeClient := fake.NewSimpleClientset(testutil.NewPod("pod1", "host1"))
+
+	// Fake clientset doesn't filter objects by fields, so just check field selector is passed
+	var fieldSelectors []string
+	fakeClient.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		fieldSelectors = append(fieldSelectors, action.(k8stesting.ListAction).GetListRestrictions().Fields.String())
+		return false, nil, nil
+	})
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	tc, err := newClient(fakeClient, nil, stopCh, useInformer)
+	require.NoError(t, err)
+
+	pods, err := tc.Pods("", "", "spec.nodeName=host1")
+	require.NoError(t, err)
+	require.Len(t, pods, 1)
+	require.Contains(t, fieldSelectors, "spec.nodeName=host1")
+
+	if useInformer {
+		// Filtered informers are created separately
+		_, err = tc.Pods("", "", "")
+		require.NoError(t, err)
+		require.Contains(t, tc.listers, "pods(,spec.nodeName=host1)")
+		require.Contains(t, tc.listers, "pods(,)")
+		require.Len(t, tc.informerFactories, 2)
+	}
+}
+
+func TestClientGetConfigMapDirectly(t *testing.T) {
+	testClientGetConfigMap(t, false)
+}
+
+func TestClientGetConfigMapUsingInformer(t *testing.T) {
+	testClientGetConfigMap(t, true)
+}
+
+func testClientGetConfigMap(t *testing.T, useInformer bool) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm1", Namespace: "ns1"},
+		Data:       map[string]string{"key": "value"},
+	}
+
+	fakeClient := fake.NewSimpleClientset(configMap)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	tc, err := newClient(fakeClient, nil, stopCh, useInformer)
+	require.NoError(t, err)
+
+	cm, err := tc.ConfigMap("ns1", "cm1")
+	require.NoError(t, err)
+	require.Equal(t, "value", cm.Data["key"])
+
+	_, err = tc.ConfigMap("ns1", "unknown")
+	require.True(t, errors.IsNotFound(err))
+
+	_, err = tc.ConfigMap("ns2", "cm1")
+	require.True(t, errors.IsNotFound(err))
+}
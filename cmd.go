@@ -31,14 +31,35 @@ import (
 	"github.com/spf13/pflag"
 )
 
+// Exit codes of diff mode
+const (
+	ExitCodeDiffChanged = 1
+	ExitCodeDiffError   = 2
+)
+
+// Exit codes of run once mode
+const (
+	ExitCodeRenderFailed  = 3
+	ExitCodeWriteFailed   = 4
+	ExitCodeCommandFailed = 5
+)
+
+// Run once mode output formats
+const (
+	OnceOutputText = "text"
+	OnceOutputJson = "json"
+)
+
 const (
 	FlagVersion              = "version"
 	FlagRunOnce              = "once"
 	FlagDryRun               = "dry-run"
+	FlagDiff                 = "diff"
 	FlagMaster               = "master"
 	FlagConfig               = "config"
 	FlagPollTime             = "poll-time"
 	FlagPollPeriod           = "poll-period"
+	FlagSourcePollPeriod     = "source-poll-period"
 	FlagTemplate             = "template"
 	FlagHelpMd               = "help-md"
 	FlagGuessKubeApiSettings = "guess-kube-api-settings"
@@ -46,6 +67,20 @@ const (
 	FlagLeftDelim            = "left-delimiter"
 	FlagRightDelim           = "right-delimiter"
 	FlagCommandTimeout       = "command-timeout"
+	FlagCommandKillTimeout   = "command-kill-timeout"
+	FlagWatch                = "watch"
+	FlagWait                 = "wait"
+	FlagStrict               = "strict"
+	FlagNamespace            = "namespace"
+	FlagFixtures             = "fixtures"
+	FlagOnceOutput           = "once-output"
+
+	FlagLeaderElect               = "leader-elect"
+	FlagLeaderElectLeaseName      = "leader-elect-lease-name"
+	FlagLeaderElectLeaseNamespace = "leader-elect-lease-namespace"
+	FlagLeaderElectLeaseDuration  = "leader-elect-lease-duration"
+	FlagLeaderElectRenewDeadline  = "leader-elect-renew-deadline"
+	FlagLeaderElectRetryPeriod    = "leader-elect-retry-period"
 )
 
 func newCmd() *cobra.Command {
@@ -55,28 +90,58 @@ func newCmd() *cobra.Command {
 		Run:  runCmd,
 	}
 	initCmd(cmd)
+	cmd.AddCommand(newTestCmd())
+	cmd.AddCommand(newExecCmd())
 	return cmd
 }
 
 func initCmd(cmd *cobra.Command) {
-	// Command-related flags set
-	f := cmd.Flags()
+	// Command-related flags set, inherited by subcommands
+	f := cmd.PersistentFlags()
 	f.Bool(FlagVersion, false, "display the version number and build timestamp")
 	f.Bool(FlagDryRun, false, "don't write template output, dump result to stdout")
-	f.Bool(FlagRunOnce, false, "run template processing once and exit")
+	f.Bool(FlagDiff, false, `show diff between template output files and rendered templates and exit,
+		with exit code 1 if any template output would change`)
+	f.Bool(FlagRunOnce, false, `run template processing once and exit, with exit code 3 if any template
+		couldn't be rendered, 4 if any output couldn't be written, 5 if any command failed`)
+	f.String(FlagOnceOutput, OnceOutputText, `run once mode output format: 'text' (log errors only) or 'json'
+		(print summary per template to stdout)`)
 	f.Bool(FlagGuessKubeApiSettings, false, "guess Kubernetes API settings from POD environment")
 	f.String(FlagMaster, "", fmt.Sprintf("Kubernetes API server address (default is %s)", DEFAULT_MASTER_HOST))
-	f.DurationP(FlagPollPeriod, "p", 15*time.Second, "Kubernetes API server poll period (0 disables server polling)")
+	f.Bool(FlagWatch, true, "watch Kubernetes API server for objects updates (use --"+FlagWatch+"=false to poll server periodically instead)")
+	f.String(FlagWait, "", `minimum and maximum time to wait for Kubernetes objects updates
+		to settle before templates rendering in the format 'min[:max]'`)
+	f.DurationP(FlagPollPeriod, "p", 15*time.Second, "Kubernetes API server poll period if not watching for updates (0 disables server polling)")
 	f.Duration(FlagPollTime, 15*time.Second, "")
 	_ = f.MarkDeprecated(FlagPollTime, "use --"+FlagPollPeriod+" instead")
+	f.Duration(FlagSourcePollPeriod, time.Minute, "HTTP(S) template sources poll period (0 disables polling)")
 	f.StringP(FlagKubeConfig, "k", "", "Kubernetes config file to use")
+	f.String(FlagFixtures, "", `render templates offline using Kubernetes objects from given
+		YAML/JSON manifests file or directory instead of Kubernetes API server`)
 	f.StringP(FlagLeftDelim, "l", "{{", "templating left delimiter")
 	f.StringP(FlagRightDelim, "r", "}}", "templating right delimiter")
 	f.StringVarP(&cfgFile, FlagConfig, "c", "", fmt.Sprintf("config file (default is ./%s.(yaml|json))", CfgFile))
 	f.StringSliceP(FlagTemplate, "t", nil, `adds a new template to watch on disk in the format
-		'templatePath:outputPath[:command]'. This option is additive
+		'templatePath:outputPath[?options][:command]', where options are
+		output file 'perms', 'user' and 'group' in URL query format
+		(e.g. 'perms=0600&user=nginx'). This option is additive
 		and may be specified multiple times for multiple templates`)
 	f.Duration(FlagCommandTimeout, 15*time.Second, "Default command execution timeout (0 to execute commands without timeout checking)")
+	f.Duration(FlagCommandKillTimeout, 5*time.Second, `time to wait for timed out command process group to exit after SIGTERM
+		before killing it with SIGKILL`)
+	f.StringP(FlagNamespace, "n", DefaultNamespace, "default namespace to query Kubernetes objects from if not specified in template")
+	f.Bool(FlagLeaderElect, false, `elect leader using Kubernetes lease, so only one of replicas writes
+		template outputs and executes commands`)
+	f.String(FlagLeaderElectLeaseName, "kube-template", "leader election lease name")
+	f.String(FlagLeaderElectLeaseNamespace, "", "leader election lease namespace (default is --"+FlagNamespace+" value)")
+	f.Duration(FlagLeaderElectLeaseDuration, 15*time.Second, "time standby replicas wait before taking over leadership")
+	f.Duration(FlagLeaderElectRenewDeadline, 10*time.Second, "time leader retries renewing leadership before giving it up")
+	f.Duration(FlagLeaderElectRetryPeriod, 2*time.Second, "time to wait between leadership acquisition or renewal attempts")
+	f.Bool(FlagStrict, false, "fail template rendering if single object requested by name is not found")
+	f.String(FlagListen, "", `address to serve /healthz, /readyz, /status and /metrics HTTP endpoints on,
+		in the format '[host]:port' (not served if empty)`)
+	f.Duration(FlagHealthTimeout, 5*time.Minute, `maximum duration of templates processing run for /healthz endpoint
+		to report healthy status (0 disables the check)`)
 	f.Bool(FlagHelpMd, false, "get help in Markdown format")
 	// Merge flags
 	pflag.CommandLine.SetNormalizeFunc(func(_ *pflag.FlagSet, name string) pflag.NormalizedName {
@@ -116,18 +181,12 @@ func runCmd(cmd *cobra.Command, _ []string) {
 		glog.Warningf("'%s' flag is deprecated, use '%s' instead", CfgPollTime, CfgPollPeriod)
 	}
 
-	getConfig := func() (*Config, error) {
-		config, err := newConfig(cmd)
-		if err != nil {
-			return nil, err
-		}
-		if len(config.TemplateDescriptors) == 0 {
-			return nil, errors.New("no templates to process")
-		}
-		return config, nil
+	onceOutput, _ := cmd.Flags().GetString(FlagOnceOutput)
+	if onceOutput != OnceOutputText && onceOutput != OnceOutputJson {
+		glog.Fatalf("invalid --%s value: %s", FlagOnceOutput, onceOutput)
 	}
 
-	config, err := getConfig()
+	config, err := getConfig(cmd)
 	if err != nil {
 		glog.Fatalf("config error: %v, exiting...", err)
 	}
@@ -137,8 +196,38 @@ func runCmd(cmd *cobra.Command, _ []string) {
 		glog.Fatalf("config couldn't be used: %v", err)
 	}
 
+	var server *Server
+	if !config.RunOnce {
+		if server, err = startServer(cmd); err != nil {
+			glog.Fatalf("can't start HTTP server: %v", err)
+		}
+		server.setApp(app)
+	}
+
+	if config.Diff {
+		_ = app.RunOnce()
+		flushLogs()
+		changed := app.printDiffSummary(os.Stdout)
+		if app.runFailed {
+			os.Exit(ExitCodeDiffError)
+		}
+		if changed {
+			os.Exit(ExitCodeDiffChanged)
+		}
+		return
+	}
+
 	if config.RunOnce {
-		app.RunOnce()
+		err := app.RunOnce()
+		flushLogs()
+		if onceOutput == OnceOutputJson {
+			if err := app.printSummary(os.Stdout, err); err != nil {
+				glog.Errorf("can't print summary: %v", err)
+			}
+		}
+		if err != nil {
+			os.Exit(runExitCode(err))
+		}
 		return
 	}
 
@@ -167,22 +256,42 @@ EventLoop:
 			break EventLoop
 		case syscall.SIGHUP:
 			glog.V(2).Infof("received %v signal, reloading config", sig)
-			config, err := getConfig()
-			if err != nil {
-				glog.Errorf("config reloading error: %v", err)
-				continue
-			}
-			newApp, err := newApp(config)
-			if err != nil {
-				glog.Errorf("reloaded config couldn't be used: %v", err)
-				continue
-			}
-			// Stop templates processing using current config
-			app.Stop()
-			<-app.doneCh
-			// Start templates processing using new config
-			app = newApp
-			go app.Start()
+			app = reloadApp(cmd, app, nil)
+			server.setApp(app)
 		}
 	}
 }
+
+// Get config with templates to process
+func getConfig(cmd *cobra.Command) (*Config, error) {
+	config, err := newConfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if len(config.TemplateDescriptors) == 0 {
+		return nil, errors.New("no templates to process")
+	}
+	return config, nil
+}
+
+// Reload config and restart templates processing using it with given run hook.
+// Returns new app, or given one if reloaded config couldn't be used.
+func reloadApp(cmd *cobra.Command, app *App, onRun runHook) *App {
+	config, err := getConfig(cmd)
+	if err != nil {
+		glog.Errorf("config reloading error: %v", err)
+		return app
+	}
+	newApp, err := newApp(config)
+	if err != nil {
+		glog.Errorf("reloaded config couldn't be used: %v", err)
+		return app
+	}
+	newApp.onRun = onRun
+	// Stop templates processing using current config
+	app.Stop()
+	<-app.doneCh
+	// Start templates processing using new config
+	go newApp.Start()
+	return newApp
+}
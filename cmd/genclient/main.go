@@ -31,41 +31,64 @@ import (
 	"errors"
 	"fmt"
 	"sort"
-	
-	corev1 "k8s.io/api/core/v1"
+{{range .GroupVersions}}
+	{{.GroupVersion}} "k8s.io/api/{{.Group}}/{{.Version}}"{{end}}
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/client-go/listers/core/v1"
+	"k8s.io/apimachinery/pkg/labels"{{range .GroupVersions}}
+	{{.GroupVersion}}listers "k8s.io/client-go/listers/{{.Group}}/{{.Version}}"{{end}}
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/golang/glog"
 )
-{{range .}}
-func (c *Client) {{.Plural}}({{if .HasNamespaces}}namespace, {{end}}selector string) ([]corev1.{{.Name}}, error) {
-	glog.V(4).Infof("fetching {{.Plural|Lower}},{{if .HasNamespaces}} namespace: %q,{{end}} selector: %q",{{if .HasNamespaces}} namespace,{{end}} selector)
+{{range .Objects}}
+func (c *Client) {{.Name|Lower}}Lister({{if .HasNamespaces}}namespace, {{end}}fieldSelector string) ({{.GroupVersion}}listers.{{.Name}}Lister, error) {
+	c.Lock()
+	defer c.Unlock()
 
-	var {{.Plural|Lower}} []corev1.{{.Name}}
+	key := fmt.Sprintf("{{.Plural|Lower}}({{if .HasNamespaces}}%s,{{end}}%s)",{{if .HasNamespaces}} namespace,{{end}} fieldSelector)
 
-	if c.useInformers {
-		c.Lock()
-		defer c.Unlock()
+	{{.Name|Lower}}Lister, found := c.listers[key]
 
-		key := fmt.Sprintf("{{.Plural|Lower}}({{if .HasNamespaces}}%s{{end}})"{{if .HasNamespaces}}, namespace{{end}})
+	if !found {
+		if fieldSelector != "" {
+			// Check field selector is supported by server before starting filtered informer
+			options := metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1}
+			if _, err := c.kubeClient.{{.Group|Title}}{{.Version|Title}}().{{.Plural}}({{if .HasNamespaces}}namespace{{end}}).List(context.TODO(), options); err != nil {
+				return nil, err
+			}
+		}
 
-		{{.Name|Lower}}Lister, found := c.listers[key]
+		{{.Name|Lower}}Informer := c.informerFactory({{if .HasNamespaces}}namespace{{else}}""{{end}}, fieldSelector).{{.Group|Title}}().{{.Version|Title}}().{{.Plural}}()
 
-		if !found {
-			{{.Name|Lower}}Informer := c.informerFactory({{if .HasNamespaces}}namespace{{else}}""{{end}}).Core().V1().{{.Plural}}()
+		{{.Name|Lower}}Handler := c.eventHandler("{{.Plural|Lower}}")
 
-			{{.Name|Lower}}Lister = {{.Name|Lower}}Informer.Lister()
+		{{.Name|Lower}}Informer.Informer().AddEventHandler({{.Name|Lower}}Handler)
 
-			c.listers[key] = {{.Name|Lower}}Lister
+		{{.Name|Lower}}Lister = {{.Name|Lower}}Informer.Lister()
 
-			go {{.Name|Lower}}Informer.Informer().Run(c.stopCh)
+		c.listers[key] = {{.Name|Lower}}Lister
 
-			if synced := cache.WaitForCacheSync(c.stopCh, {{.Name|Lower}}Informer.Informer().HasSynced); !synced {
-				return nil, errors.New("{{.Name|Lower}} cache sync failed")
-			}
+		go {{.Name|Lower}}Informer.Informer().Run(c.stopCh)
+
+		if synced := cache.WaitForCacheSync(c.stopCh, {{.Name|Lower}}Informer.Informer().HasSynced); !synced {
+			return nil, errors.New("{{.Name|Lower}} cache sync failed")
+		}
+
+		{{.Name|Lower}}Handler.informerSynced({{.Name|Lower}}Informer.Informer().GetStore())
+	}
+
+	return {{.Name|Lower}}Lister.({{.GroupVersion}}listers.{{.Name}}Lister), nil
+}
+
+func (c *Client) {{.Plural}}({{if .HasNamespaces}}namespace, {{end}}selector, fieldSelector string) ([]{{.GroupVersion}}.{{.Name}}, error) {
+	glog.V(4).Infof("fetching {{.Plural|Lower}},{{if .HasNamespaces}} namespace: %q,{{end}} selector: %q, field selector: %q",{{if .HasNamespaces}} namespace,{{end}} selector, fieldSelector)
+
+	var {{.Plural|Lower}} []{{.GroupVersion}}.{{.Name}}
+
+	if c.useInformers {
+		{{.Name|Lower}}Lister, err := c.{{.Name|Lower}}Lister({{if .HasNamespaces}}namespace, {{end}}fieldSelector)
+		if err != nil {
+			return nil, err
 		}
 
 		s, err := labels.Parse(selector)
@@ -73,7 +96,7 @@ func (c *Client) {{.Plural}}({{if .HasNamespaces}}namespace, {{end}}selector str
 			return nil, err
 		}
 
-		es, err := {{.Name|Lower}}Lister.(v1.{{.Name}}Lister).{{if .HasNamespaces}}{{.Plural}}(namespace).{{end}}List(s)
+		es, err := {{.Name|Lower}}Lister.{{if .HasNamespaces}}{{.Plural}}(namespace).{{end}}List(s)
 		if err != nil {
 			return nil, err
 		}
@@ -82,9 +105,9 @@ func (c *Client) {{.Plural}}({{if .HasNamespaces}}namespace, {{end}}selector str
 			{{.Plural|Lower}} = append({{.Plural|Lower}}, *e)
 		}
 	} else {
-		options := metav1.ListOptions{LabelSelector: selector}
+		options := metav1.ListOptions{LabelSelector: selector, FieldSelector: fieldSelector}
 
-		{{.Name|Lower}}List, err := c.kubeClient.CoreV1().{{.Plural}}({{if .HasNamespaces}}namespace{{end}}).List(context.TODO(), options)
+		{{.Name|Lower}}List, err := c.kubeClient.{{.Group|Title}}{{.Version|Title}}().{{.Plural}}({{if .HasNamespaces}}namespace{{end}}).List(context.TODO(), options)
 		if err != nil {
 			return nil, err
 		}
@@ -94,11 +117,29 @@ func (c *Client) {{.Plural}}({{if .HasNamespaces}}namespace, {{end}}selector str
 
 	// Make list order stable
 	sort.Slice({{.Plural|Lower}}, func(i, j int) bool {
+		if {{.Plural|Lower}}[i].Namespace != {{.Plural|Lower}}[j].Namespace {
+			return {{.Plural|Lower}}[i].Namespace < {{.Plural|Lower}}[j].Namespace
+		}
 		return {{.Plural|Lower}}[i].Name < {{.Plural|Lower}}[j].Name
 	})
 
 	return {{.Plural|Lower}}, nil
 }
+
+func (c *Client) {{.Singular}}({{if .HasNamespaces}}namespace, {{end}}name string) (*{{.GroupVersion}}.{{.Name}}, error) {
+	glog.V(4).Infof("fetching {{.Singular|Lower}},{{if .HasNamespaces}} namespace: %q,{{end}} name: %q",{{if .HasNamespaces}} namespace,{{end}} name)
+
+	if c.useInformers {
+		{{.Name|Lower}}Lister, err := c.{{.Name|Lower}}Lister({{if .HasNamespaces}}namespace, {{end}}"")
+		if err != nil {
+			return nil, err
+		}
+
+		return {{.Name|Lower}}Lister.{{if .HasNamespaces}}{{.Plural}}(namespace).{{end}}Get(name)
+	}
+
+	return c.kubeClient.{{.Group|Title}}{{.Version|Title}}().{{.Plural}}({{if .HasNamespaces}}namespace{{end}}).Get(context.TODO(), name, metav1.GetOptions{})
+}
 {{end}}
 `
 )
@@ -106,9 +147,30 @@ func (c *Client) {{.Plural}}({{if .HasNamespaces}}namespace, {{end}}selector str
 type Object struct {
 	Name          string `json:"name"`
 	Plural        string `json:"plural"`
+	SingularName  string `json:"singular"`
+	Group         string `json:"group"`
+	Version       string `json:"version"`
 	HasNamespaces bool   `json:"namespaces"`
 }
 
+// API group and version, used as package alias (e.g. 'corev1')
+func (o Object) GroupVersion() string {
+	return o.Group + o.Version
+}
+
+// Name of single object functions, differs from object name if it's the same as plural one
+func (o Object) Singular() string {
+	if o.SingularName != "" {
+		return o.SingularName
+	}
+	return o.Name
+}
+
+type Data struct {
+	Objects       []Object
+	GroupVersions []Object
+}
+
 func main() {
 	r, err := os.Open("objects.json")
 	checkError(err)
@@ -118,8 +180,19 @@ func main() {
 	err = json.NewDecoder(r).Decode(&objects)
 	checkError(err)
 
+	// Collect distinct API groups and versions to import
+	data := Data{Objects: objects}
+	groupVersions := make(map[string]bool)
+	for _, o := range objects {
+		if !groupVersions[o.GroupVersion()] {
+			groupVersions[o.GroupVersion()] = true
+			data.GroupVersions = append(data.GroupVersions, o)
+		}
+	}
+
 	t, err := template.New("genclient").Funcs(template.FuncMap{
 		"Lower": strings.ToLower,
+		"Title": strings.Title,
 	}).Parse(tmpl)
 	checkError(err)
 
@@ -127,7 +200,7 @@ func main() {
 	checkError(err)
 	defer w.Close()
 
-	err = t.Execute(w, objects)
+	err = t.Execute(w, data)
 	checkError(err)
 }
 
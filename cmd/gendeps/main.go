@@ -27,23 +27,42 @@ const (
 package main
 
 import (
-	"fmt"
-
-	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+{{range .GroupVersions}}
+	{{.GroupVersion}} "k8s.io/api/{{.Group}}/{{.Version}}"{{end}}
 )
-{{range .}}
-func (dm *DependencyManager) {{.Plural}}({{if .HasNamespaces}}namespace, {{end}}selector string) ([]corev1.{{.Name}}, error) {
-	key := fmt.Sprintf("{{.Plural|Lower}}(%s{{if .HasNamespaces}},%s{{end}})"{{if .HasNamespaces}}, namespace{{end}}, selector)
+{{range .Objects}}
+func (dm *DependencyManager) {{.Plural}}({{if .HasNamespaces}}namespace, {{end}}selector, fieldSelector string) ([]{{.GroupVersion}}.{{.Name}}, error) {
+	key := dependencyKey{resource: "{{.Plural|Lower}}",{{if .HasNamespaces}} namespace: namespace,{{end}} selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
 	if value, found := dm.cachedDependency(key); found {
-		return value.([]corev1.{{.Name}}), nil
+		return value.([]{{.GroupVersion}}.{{.Name}}), nil
 	}
-	{{.Plural|Lower}}, err := dm.client.{{.Plural}}({{if .HasNamespaces}}namespace, {{end}}selector)
+	{{.Plural|Lower}}, err := dm.client.{{.Plural}}({{if .HasNamespaces}}namespace, {{end}}selector, fieldSelector)
 	if err != nil {
 		return nil, err
 	}
 	dm.cacheDependency(key, {{.Plural|Lower}})
 	return {{.Plural|Lower}}, nil
 }
+
+func (dm *DependencyManager) {{.Singular}}({{if .HasNamespaces}}namespace, {{end}}name string) (*{{.GroupVersion}}.{{.Name}}, error) {
+	key := dependencyKey{resource: "{{.Plural|Lower}}",{{if .HasNamespaces}} namespace: namespace,{{end}} name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*{{.GroupVersion}}.{{.Name}}), nil
+	}
+	{{.Singular|Lower}}, err := dm.client.{{.Singular}}({{if .HasNamespaces}}namespace, {{end}}name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		{{.Singular|Lower}} = nil
+	}
+	dm.cacheDependency(key, {{.Singular|Lower}})
+	return {{.Singular|Lower}}, nil
+}
 {{end}}
 `
 )
@@ -51,9 +70,30 @@ func (dm *DependencyManager) {{.Plural}}({{if .HasNamespaces}}namespace, {{end}}
 type Object struct {
 	Name          string `json:"name"`
 	Plural        string `json:"plural"`
+	SingularName  string `json:"singular"`
+	Group         string `json:"group"`
+	Version       string `json:"version"`
 	HasNamespaces bool   `json:"namespaces"`
 }
 
+// API group and version, used as package alias (e.g. 'corev1')
+func (o Object) GroupVersion() string {
+	return o.Group + o.Version
+}
+
+// Name of single object functions, differs from object name if it's the same as plural one
+func (o Object) Singular() string {
+	if o.SingularName != "" {
+		return o.SingularName
+	}
+	return o.Name
+}
+
+type Data struct {
+	Objects       []Object
+	GroupVersions []Object
+}
+
 func main() {
 	r, err := os.Open("objects.json")
 	checkError(err)
@@ -63,8 +103,19 @@ func main() {
 	err = json.NewDecoder(r).Decode(&objects)
 	checkError(err)
 
+	// Collect distinct API groups and versions to import
+	data := Data{Objects: objects}
+	groupVersions := make(map[string]bool)
+	for _, o := range objects {
+		if !groupVersions[o.GroupVersion()] {
+			groupVersions[o.GroupVersion()] = true
+			data.GroupVersions = append(data.GroupVersions, o)
+		}
+	}
+
 	t, err := template.New("gendeps").Funcs(template.FuncMap{
 		"Lower": strings.ToLower,
+		"Title": strings.Title,
 	}).Parse(tmpl)
 	checkError(err)
 
@@ -72,7 +123,7 @@ func main() {
 	checkError(err)
 	defer w.Close()
 
-	err = t.Execute(w, objects)
+	err = t.Execute(w, data)
 	checkError(err)
 }
 
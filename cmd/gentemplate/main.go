@@ -27,23 +27,68 @@ const (
 package main
 
 import (
-	corev1 "k8s.io/api/core/v1"
+	"sort"
+{{range .GroupVersions}}
+	{{.GroupVersion}} "k8s.io/api/{{.Group}}/{{.Version}}"{{end}}
 )
 
 func kubeObjectsFuncMap(dm *DependencyManager) map[string]interface{} {
-	return map[string]interface{}{ {{range .}}
-		"{{.Plural|Lower}}": {{.Plural|Lower}}(dm),{{end}}
+	return map[string]interface{}{ {{range .Objects}}
+		"{{.Plural|Lower}}": {{.Plural|Lower}}(dm),
+		"{{.Singular|Lower}}": {{.Singular|Lower}}(dm),{{end}}
 	}
 }
-{{range .}}
+{{range .Objects}}
 // {{"{{"}}{{.Plural|Lower}} "selector"{{if .HasNamespaces}} "namespace"{{end}}{{"}}"}}
-func {{.Plural|Lower}}(dm *DependencyManager) func(...string) ([]corev1.{{.Name}}, error) {
-	return func(s ...string) ([]corev1.{{.Name}}, error) {
-		if {{if .HasNamespaces}}namespace, {{end}}selector, err := parse{{if .HasNamespaces}}Namespace{{end}}Selector(s...); err == nil {
-			return dm.{{.Plural}}({{if .HasNamespaces}}namespace, {{end}}selector)
+// {{"{{"}}{{.Plural|Lower}} (dict "selector" "selector" "fields" "fields"{{if .HasNamespaces}} "namespace" "namespace"{{end}}){{"}}"}}
+func {{.Plural|Lower}}(dm *DependencyManager) func(...interface{}) ([]{{.GroupVersion}}.{{.Name}}, error) {
+	return func(s ...interface{}) ([]{{.GroupVersion}}.{{.Name}}, error) {
+{{- if .HasNamespaces}}
+		namespaces, selector, fieldSelector, err := parseNamespaceSelector(dm.namespace, s...)
+		if err != nil {
+			return nil, err
+		}
+		if len(namespaces) == 1 {
+			return dm.{{.Plural}}(namespaces[0], selector, fieldSelector)
+		}
+		var {{.Plural|Lower}} []{{.GroupVersion}}.{{.Name}}
+		for _, namespace := range namespaces {
+			o, err := dm.{{.Plural}}(namespace, selector, fieldSelector)
+			if err != nil {
+				return nil, err
+			}
+			{{.Plural|Lower}} = append({{.Plural|Lower}}, o...)
+		}
+		// Make list order stable
+		sort.Slice({{.Plural|Lower}}, func(i, j int) bool {
+			if {{.Plural|Lower}}[i].Namespace != {{.Plural|Lower}}[j].Namespace {
+				return {{.Plural|Lower}}[i].Namespace < {{.Plural|Lower}}[j].Namespace
+			}
+			return {{.Plural|Lower}}[i].Name < {{.Plural|Lower}}[j].Name
+		})
+		return {{.Plural|Lower}}, nil
+{{- else}}
+		if selector, fieldSelector, err := parseSelector(s...); err == nil {
+			return dm.{{.Plural}}(selector, fieldSelector)
 		} else {
 			return nil, err
 		}
+{{- end}}
+	}
+}
+
+// {{"{{"}}{{.Singular|Lower}} "name"{{if .HasNamespaces}} "namespace"{{end}}{{"}}"}}
+func {{.Singular|Lower}}(dm *DependencyManager) func(string{{if .HasNamespaces}}, ...string{{end}}) (*{{.GroupVersion}}.{{.Name}}, error) {
+	return func(name string{{if .HasNamespaces}}, s ...string{{end}}) (*{{.GroupVersion}}.{{.Name}}, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}{{if .HasNamespaces}}
+		if namespace, err := parseNamespace(dm.namespace, s...); err == nil {
+			return dm.{{.Singular}}(namespace, name)
+		} else {
+			return nil, err
+		}{{else}}
+		return dm.{{.Singular}}(name){{end}}
 	}
 }
 {{end}}
@@ -53,9 +98,30 @@ func {{.Plural|Lower}}(dm *DependencyManager) func(...string) ([]corev1.{{.Name}
 type Object struct {
 	Name          string `json:"name"`
 	Plural        string `json:"plural"`
+	SingularName  string `json:"singular"`
+	Group         string `json:"group"`
+	Version       string `json:"version"`
 	HasNamespaces bool   `json:"namespaces"`
 }
 
+// API group and version, used as package alias (e.g. 'corev1')
+func (o Object) GroupVersion() string {
+	return o.Group + o.Version
+}
+
+// Name of single object functions, differs from object name if it's the same as plural one
+func (o Object) Singular() string {
+	if o.SingularName != "" {
+		return o.SingularName
+	}
+	return o.Name
+}
+
+type Data struct {
+	Objects       []Object
+	GroupVersions []Object
+}
+
 func main() {
 	r, err := os.Open("objects.json")
 	checkError(err)
@@ -65,8 +131,19 @@ func main() {
 	err = json.NewDecoder(r).Decode(&objects)
 	checkError(err)
 
+	// Collect distinct API groups and versions to import
+	data := Data{Objects: objects}
+	groupVersions := make(map[string]bool)
+	for _, o := range objects {
+		if !groupVersions[o.GroupVersion()] {
+			groupVersions[o.GroupVersion()] = true
+			data.GroupVersions = append(data.GroupVersions, o)
+		}
+	}
+
 	t, err := template.New("gentemplate").Funcs(template.FuncMap{
 		"Lower": strings.ToLower,
+		"Title": strings.Title,
 	}).Parse(tmpl)
 	checkError(err)
 
@@ -74,7 +151,7 @@ func main() {
 	checkError(err)
 	defer w.Close()
 
-	err = t.Execute(w, objects)
+	err = t.Execute(w, data)
 	checkError(err)
 }
 
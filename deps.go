@@ -15,7 +15,12 @@
 package main
 
 import (
+	"fmt"
 	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 type DependencyManager struct {
@@ -23,32 +28,124 @@ type DependencyManager struct {
 	// Kubernetes client
 	client *Client
 	// Cached dependencies
-	cachedDeps map[string]interface{}
+	cachedDeps map[dependencyKey]interface{}
+	// Dependencies used since recording start (nil if not recording)
+	recordedDeps map[dependencyKey]bool
+	// Return error if single requested object is not found
+	strict bool
+	// Namespace to use if not specified in template
+	namespace string
+}
+
+// Kubernetes objects dependency key
+type dependencyKey struct {
+	// Resource name (lowercase plural, e.g. 'pods', or 'group/version/resource' for arbitrary resources)
+	resource string
+	// Namespace (empty for all namespaces or non-namespaced resources)
+	namespace string
+	// Label selector
+	selector string
+	// Field selector
+	fields string
+	// Object name (for single object dependency)
+	name string
+}
+
+func (k dependencyKey) String() string {
+	if k.name != "" {
+		return fmt.Sprintf("%s(%s,%s)", k.resource, k.namespace, k.name)
+	}
+	return fmt.Sprintf("%s(%s,%s,%s)", k.resource, k.namespace, k.selector, k.fields)
+}
+
+// Check given Kubernetes object change can affect the dependency
+func (k dependencyKey) affectedBy(change objectChange) bool {
+	if k.resource != change.resource {
+		return false
+	}
+	if k.namespace != "" && k.namespace != change.namespace {
+		return false
+	}
+	if k.name != "" {
+		return k.name == change.name
+	}
+	s, err := labels.Parse(k.selector)
+	if err != nil {
+		// Can't say for sure, assume affected
+		return true
+	}
+	// Field selector is not checked since object fields are not known,
+	// object is assumed to match it if labels are matched
+	return s.Matches(change.labels)
 }
 
 func newDependencyManager(client *Client) *DependencyManager {
 	return &DependencyManager{
 		client:     client,
-		cachedDeps: make(map[string]interface{}),
+		cachedDeps: make(map[dependencyKey]interface{}),
+		namespace:  DefaultNamespace,
 	}
 }
 
 func (dm *DependencyManager) flushCachedDependencies() {
 	dm.RLock()
 	defer dm.RUnlock()
-	dm.cachedDeps = make(map[string]interface{})
+	dm.cachedDeps = make(map[dependencyKey]interface{})
+}
+
+// Start recording of used dependencies
+func (dm *DependencyManager) startRecording() {
+	dm.Lock()
+	defer dm.Unlock()
+	dm.recordedDeps = make(map[dependencyKey]bool)
+}
+
+// Stop recording of used dependencies and return dependencies recorded
+func (dm *DependencyManager) stopRecording() map[dependencyKey]bool {
+	dm.Lock()
+	defer dm.Unlock()
+	deps := dm.recordedDeps
+	dm.recordedDeps = nil
+	return deps
 }
 
-func (dm *DependencyManager) cachedDependency(key string) (interface{}, bool) {
+func (dm *DependencyManager) recordDependency(key dependencyKey) {
+	dm.Lock()
+	defer dm.Unlock()
+	if dm.recordedDeps != nil {
+		dm.recordedDeps[key] = true
+	}
+}
+
+func (dm *DependencyManager) cachedDependency(key dependencyKey) (interface{}, bool) {
 	dm.RLock()
 	defer dm.RUnlock()
 	value, found := dm.cachedDeps[key]
+	if found {
+		dependencyCacheHits.Inc()
+	} else {
+		dependencyCacheMisses.Inc()
+	}
 	return value, found
 }
 
-func (dm *DependencyManager) cacheDependency(key string, dep interface{}) {
+func (dm *DependencyManager) cacheDependency(key dependencyKey, dep interface{}) {
 	dm.Lock()
 	defer dm.Unlock()
 
 	dm.cachedDeps[key] = dep
 }
+
+func (dm *DependencyManager) Resources(gvr schema.GroupVersionResource, namespace, selector, fieldSelector string) ([]unstructured.Unstructured, error) {
+	key := dependencyKey{resource: resourceName(gvr), namespace: namespace, selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.([]unstructured.Unstructured), nil
+	}
+	resources, err := dm.client.Resources(gvr, namespace, selector, fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	dm.cacheDependency(key, resources)
+	return resources, nil
+}
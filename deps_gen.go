@@ -17,17 +17,19 @@
 package main
 
 import (
-	"fmt"
+	"k8s.io/apimachinery/pkg/api/errors"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 )
 
-func (dm *DependencyManager) Pods(namespace, selector string) ([]corev1.Pod, error) {
-	key := fmt.Sprintf("pods(%s,%s)", namespace, selector)
+func (dm *DependencyManager) Pods(namespace, selector, fieldSelector string) ([]corev1.Pod, error) {
+	key := dependencyKey{resource: "pods", namespace: namespace, selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
 	if value, found := dm.cachedDependency(key); found {
 		return value.([]corev1.Pod), nil
 	}
-	pods, err := dm.client.Pods(namespace, selector)
+	pods, err := dm.client.Pods(namespace, selector, fieldSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -35,12 +37,31 @@ func (dm *DependencyManager) Pods(namespace, selector string) ([]corev1.Pod, err
 	return pods, nil
 }
 
-func (dm *DependencyManager) Services(namespace, selector string) ([]corev1.Service, error) {
-	key := fmt.Sprintf("services(%s,%s)", namespace, selector)
+func (dm *DependencyManager) Pod(namespace, name string) (*corev1.Pod, error) {
+	key := dependencyKey{resource: "pods", namespace: namespace, name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*corev1.Pod), nil
+	}
+	pod, err := dm.client.Pod(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		pod = nil
+	}
+	dm.cacheDependency(key, pod)
+	return pod, nil
+}
+
+func (dm *DependencyManager) Services(namespace, selector, fieldSelector string) ([]corev1.Service, error) {
+	key := dependencyKey{resource: "services", namespace: namespace, selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
 	if value, found := dm.cachedDependency(key); found {
 		return value.([]corev1.Service), nil
 	}
-	services, err := dm.client.Services(namespace, selector)
+	services, err := dm.client.Services(namespace, selector, fieldSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -48,12 +69,31 @@ func (dm *DependencyManager) Services(namespace, selector string) ([]corev1.Serv
 	return services, nil
 }
 
-func (dm *DependencyManager) ReplicationControllers(namespace, selector string) ([]corev1.ReplicationController, error) {
-	key := fmt.Sprintf("replicationcontrollers(%s,%s)", namespace, selector)
+func (dm *DependencyManager) Service(namespace, name string) (*corev1.Service, error) {
+	key := dependencyKey{resource: "services", namespace: namespace, name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*corev1.Service), nil
+	}
+	service, err := dm.client.Service(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		service = nil
+	}
+	dm.cacheDependency(key, service)
+	return service, nil
+}
+
+func (dm *DependencyManager) ReplicationControllers(namespace, selector, fieldSelector string) ([]corev1.ReplicationController, error) {
+	key := dependencyKey{resource: "replicationcontrollers", namespace: namespace, selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
 	if value, found := dm.cachedDependency(key); found {
 		return value.([]corev1.ReplicationController), nil
 	}
-	replicationcontrollers, err := dm.client.ReplicationControllers(namespace, selector)
+	replicationcontrollers, err := dm.client.ReplicationControllers(namespace, selector, fieldSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -61,12 +101,31 @@ func (dm *DependencyManager) ReplicationControllers(namespace, selector string)
 	return replicationcontrollers, nil
 }
 
-func (dm *DependencyManager) Events(namespace, selector string) ([]corev1.Event, error) {
-	key := fmt.Sprintf("events(%s,%s)", namespace, selector)
+func (dm *DependencyManager) ReplicationController(namespace, name string) (*corev1.ReplicationController, error) {
+	key := dependencyKey{resource: "replicationcontrollers", namespace: namespace, name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*corev1.ReplicationController), nil
+	}
+	replicationcontroller, err := dm.client.ReplicationController(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		replicationcontroller = nil
+	}
+	dm.cacheDependency(key, replicationcontroller)
+	return replicationcontroller, nil
+}
+
+func (dm *DependencyManager) Events(namespace, selector, fieldSelector string) ([]corev1.Event, error) {
+	key := dependencyKey{resource: "events", namespace: namespace, selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
 	if value, found := dm.cachedDependency(key); found {
 		return value.([]corev1.Event), nil
 	}
-	events, err := dm.client.Events(namespace, selector)
+	events, err := dm.client.Events(namespace, selector, fieldSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -74,12 +133,31 @@ func (dm *DependencyManager) Events(namespace, selector string) ([]corev1.Event,
 	return events, nil
 }
 
-func (dm *DependencyManager) Endpoints(namespace, selector string) ([]corev1.Endpoints, error) {
-	key := fmt.Sprintf("endpoints(%s,%s)", namespace, selector)
+func (dm *DependencyManager) Event(namespace, name string) (*corev1.Event, error) {
+	key := dependencyKey{resource: "events", namespace: namespace, name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*corev1.Event), nil
+	}
+	event, err := dm.client.Event(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		event = nil
+	}
+	dm.cacheDependency(key, event)
+	return event, nil
+}
+
+func (dm *DependencyManager) Endpoints(namespace, selector, fieldSelector string) ([]corev1.Endpoints, error) {
+	key := dependencyKey{resource: "endpoints", namespace: namespace, selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
 	if value, found := dm.cachedDependency(key); found {
 		return value.([]corev1.Endpoints), nil
 	}
-	endpoints, err := dm.client.Endpoints(namespace, selector)
+	endpoints, err := dm.client.Endpoints(namespace, selector, fieldSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -87,12 +165,31 @@ func (dm *DependencyManager) Endpoints(namespace, selector string) ([]corev1.End
 	return endpoints, nil
 }
 
-func (dm *DependencyManager) Nodes(selector string) ([]corev1.Node, error) {
-	key := fmt.Sprintf("nodes(%s)", selector)
+func (dm *DependencyManager) Endpoint(namespace, name string) (*corev1.Endpoints, error) {
+	key := dependencyKey{resource: "endpoints", namespace: namespace, name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*corev1.Endpoints), nil
+	}
+	endpoint, err := dm.client.Endpoint(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		endpoint = nil
+	}
+	dm.cacheDependency(key, endpoint)
+	return endpoint, nil
+}
+
+func (dm *DependencyManager) Nodes(selector, fieldSelector string) ([]corev1.Node, error) {
+	key := dependencyKey{resource: "nodes", selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
 	if value, found := dm.cachedDependency(key); found {
 		return value.([]corev1.Node), nil
 	}
-	nodes, err := dm.client.Nodes(selector)
+	nodes, err := dm.client.Nodes(selector, fieldSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -100,12 +197,31 @@ func (dm *DependencyManager) Nodes(selector string) ([]corev1.Node, error) {
 	return nodes, nil
 }
 
-func (dm *DependencyManager) Namespaces(selector string) ([]corev1.Namespace, error) {
-	key := fmt.Sprintf("namespaces(%s)", selector)
+func (dm *DependencyManager) Node(name string) (*corev1.Node, error) {
+	key := dependencyKey{resource: "nodes", name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*corev1.Node), nil
+	}
+	node, err := dm.client.Node(name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		node = nil
+	}
+	dm.cacheDependency(key, node)
+	return node, nil
+}
+
+func (dm *DependencyManager) Namespaces(selector, fieldSelector string) ([]corev1.Namespace, error) {
+	key := dependencyKey{resource: "namespaces", selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
 	if value, found := dm.cachedDependency(key); found {
 		return value.([]corev1.Namespace), nil
 	}
-	namespaces, err := dm.client.Namespaces(selector)
+	namespaces, err := dm.client.Namespaces(selector, fieldSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -113,12 +229,31 @@ func (dm *DependencyManager) Namespaces(selector string) ([]corev1.Namespace, er
 	return namespaces, nil
 }
 
-func (dm *DependencyManager) ComponentStatuses(selector string) ([]corev1.ComponentStatus, error) {
-	key := fmt.Sprintf("componentstatuses(%s)", selector)
+func (dm *DependencyManager) Namespace(name string) (*corev1.Namespace, error) {
+	key := dependencyKey{resource: "namespaces", name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*corev1.Namespace), nil
+	}
+	namespace, err := dm.client.Namespace(name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		namespace = nil
+	}
+	dm.cacheDependency(key, namespace)
+	return namespace, nil
+}
+
+func (dm *DependencyManager) ComponentStatuses(selector, fieldSelector string) ([]corev1.ComponentStatus, error) {
+	key := dependencyKey{resource: "componentstatuses", selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
 	if value, found := dm.cachedDependency(key); found {
 		return value.([]corev1.ComponentStatus), nil
 	}
-	componentstatuses, err := dm.client.ComponentStatuses(selector)
+	componentstatuses, err := dm.client.ComponentStatuses(selector, fieldSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -126,12 +261,31 @@ func (dm *DependencyManager) ComponentStatuses(selector string) ([]corev1.Compon
 	return componentstatuses, nil
 }
 
-func (dm *DependencyManager) ConfigMaps(namespace, selector string) ([]corev1.ConfigMap, error) {
-	key := fmt.Sprintf("configmaps(%s,%s)", namespace, selector)
+func (dm *DependencyManager) ComponentStatus(name string) (*corev1.ComponentStatus, error) {
+	key := dependencyKey{resource: "componentstatuses", name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*corev1.ComponentStatus), nil
+	}
+	componentstatus, err := dm.client.ComponentStatus(name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		componentstatus = nil
+	}
+	dm.cacheDependency(key, componentstatus)
+	return componentstatus, nil
+}
+
+func (dm *DependencyManager) ConfigMaps(namespace, selector, fieldSelector string) ([]corev1.ConfigMap, error) {
+	key := dependencyKey{resource: "configmaps", namespace: namespace, selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
 	if value, found := dm.cachedDependency(key); found {
 		return value.([]corev1.ConfigMap), nil
 	}
-	configmaps, err := dm.client.ConfigMaps(namespace, selector)
+	configmaps, err := dm.client.ConfigMaps(namespace, selector, fieldSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -139,12 +293,31 @@ func (dm *DependencyManager) ConfigMaps(namespace, selector string) ([]corev1.Co
 	return configmaps, nil
 }
 
-func (dm *DependencyManager) LimitRanges(namespace, selector string) ([]corev1.LimitRange, error) {
-	key := fmt.Sprintf("limitranges(%s,%s)", namespace, selector)
+func (dm *DependencyManager) ConfigMap(namespace, name string) (*corev1.ConfigMap, error) {
+	key := dependencyKey{resource: "configmaps", namespace: namespace, name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*corev1.ConfigMap), nil
+	}
+	configmap, err := dm.client.ConfigMap(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		configmap = nil
+	}
+	dm.cacheDependency(key, configmap)
+	return configmap, nil
+}
+
+func (dm *DependencyManager) LimitRanges(namespace, selector, fieldSelector string) ([]corev1.LimitRange, error) {
+	key := dependencyKey{resource: "limitranges", namespace: namespace, selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
 	if value, found := dm.cachedDependency(key); found {
 		return value.([]corev1.LimitRange), nil
 	}
-	limitranges, err := dm.client.LimitRanges(namespace, selector)
+	limitranges, err := dm.client.LimitRanges(namespace, selector, fieldSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -152,12 +325,31 @@ func (dm *DependencyManager) LimitRanges(namespace, selector string) ([]corev1.L
 	return limitranges, nil
 }
 
-func (dm *DependencyManager) PersistentVolumes(selector string) ([]corev1.PersistentVolume, error) {
-	key := fmt.Sprintf("persistentvolumes(%s)", selector)
+func (dm *DependencyManager) LimitRange(namespace, name string) (*corev1.LimitRange, error) {
+	key := dependencyKey{resource: "limitranges", namespace: namespace, name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*corev1.LimitRange), nil
+	}
+	limitrange, err := dm.client.LimitRange(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		limitrange = nil
+	}
+	dm.cacheDependency(key, limitrange)
+	return limitrange, nil
+}
+
+func (dm *DependencyManager) PersistentVolumes(selector, fieldSelector string) ([]corev1.PersistentVolume, error) {
+	key := dependencyKey{resource: "persistentvolumes", selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
 	if value, found := dm.cachedDependency(key); found {
 		return value.([]corev1.PersistentVolume), nil
 	}
-	persistentvolumes, err := dm.client.PersistentVolumes(selector)
+	persistentvolumes, err := dm.client.PersistentVolumes(selector, fieldSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -165,12 +357,31 @@ func (dm *DependencyManager) PersistentVolumes(selector string) ([]corev1.Persis
 	return persistentvolumes, nil
 }
 
-func (dm *DependencyManager) PersistentVolumeClaims(namespace, selector string) ([]corev1.PersistentVolumeClaim, error) {
-	key := fmt.Sprintf("persistentvolumeclaims(%s,%s)", namespace, selector)
+func (dm *DependencyManager) PersistentVolume(name string) (*corev1.PersistentVolume, error) {
+	key := dependencyKey{resource: "persistentvolumes", name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*corev1.PersistentVolume), nil
+	}
+	persistentvolume, err := dm.client.PersistentVolume(name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		persistentvolume = nil
+	}
+	dm.cacheDependency(key, persistentvolume)
+	return persistentvolume, nil
+}
+
+func (dm *DependencyManager) PersistentVolumeClaims(namespace, selector, fieldSelector string) ([]corev1.PersistentVolumeClaim, error) {
+	key := dependencyKey{resource: "persistentvolumeclaims", namespace: namespace, selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
 	if value, found := dm.cachedDependency(key); found {
 		return value.([]corev1.PersistentVolumeClaim), nil
 	}
-	persistentvolumeclaims, err := dm.client.PersistentVolumeClaims(namespace, selector)
+	persistentvolumeclaims, err := dm.client.PersistentVolumeClaims(namespace, selector, fieldSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -178,12 +389,31 @@ func (dm *DependencyManager) PersistentVolumeClaims(namespace, selector string)
 	return persistentvolumeclaims, nil
 }
 
-func (dm *DependencyManager) PodTemplates(namespace, selector string) ([]corev1.PodTemplate, error) {
-	key := fmt.Sprintf("podtemplates(%s,%s)", namespace, selector)
+func (dm *DependencyManager) PersistentVolumeClaim(namespace, name string) (*corev1.PersistentVolumeClaim, error) {
+	key := dependencyKey{resource: "persistentvolumeclaims", namespace: namespace, name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*corev1.PersistentVolumeClaim), nil
+	}
+	persistentvolumeclaim, err := dm.client.PersistentVolumeClaim(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		persistentvolumeclaim = nil
+	}
+	dm.cacheDependency(key, persistentvolumeclaim)
+	return persistentvolumeclaim, nil
+}
+
+func (dm *DependencyManager) PodTemplates(namespace, selector, fieldSelector string) ([]corev1.PodTemplate, error) {
+	key := dependencyKey{resource: "podtemplates", namespace: namespace, selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
 	if value, found := dm.cachedDependency(key); found {
 		return value.([]corev1.PodTemplate), nil
 	}
-	podtemplates, err := dm.client.PodTemplates(namespace, selector)
+	podtemplates, err := dm.client.PodTemplates(namespace, selector, fieldSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -191,12 +421,31 @@ func (dm *DependencyManager) PodTemplates(namespace, selector string) ([]corev1.
 	return podtemplates, nil
 }
 
-func (dm *DependencyManager) ResourceQuotas(namespace, selector string) ([]corev1.ResourceQuota, error) {
-	key := fmt.Sprintf("resourcequotas(%s,%s)", namespace, selector)
+func (dm *DependencyManager) PodTemplate(namespace, name string) (*corev1.PodTemplate, error) {
+	key := dependencyKey{resource: "podtemplates", namespace: namespace, name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*corev1.PodTemplate), nil
+	}
+	podtemplate, err := dm.client.PodTemplate(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		podtemplate = nil
+	}
+	dm.cacheDependency(key, podtemplate)
+	return podtemplate, nil
+}
+
+func (dm *DependencyManager) ResourceQuotas(namespace, selector, fieldSelector string) ([]corev1.ResourceQuota, error) {
+	key := dependencyKey{resource: "resourcequotas", namespace: namespace, selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
 	if value, found := dm.cachedDependency(key); found {
 		return value.([]corev1.ResourceQuota), nil
 	}
-	resourcequotas, err := dm.client.ResourceQuotas(namespace, selector)
+	resourcequotas, err := dm.client.ResourceQuotas(namespace, selector, fieldSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -204,12 +453,31 @@ func (dm *DependencyManager) ResourceQuotas(namespace, selector string) ([]corev
 	return resourcequotas, nil
 }
 
-func (dm *DependencyManager) Secrets(namespace, selector string) ([]corev1.Secret, error) {
-	key := fmt.Sprintf("secrets(%s,%s)", namespace, selector)
+func (dm *DependencyManager) ResourceQuota(namespace, name string) (*corev1.ResourceQuota, error) {
+	key := dependencyKey{resource: "resourcequotas", namespace: namespace, name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*corev1.ResourceQuota), nil
+	}
+	resourcequota, err := dm.client.ResourceQuota(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		resourcequota = nil
+	}
+	dm.cacheDependency(key, resourcequota)
+	return resourcequota, nil
+}
+
+func (dm *DependencyManager) Secrets(namespace, selector, fieldSelector string) ([]corev1.Secret, error) {
+	key := dependencyKey{resource: "secrets", namespace: namespace, selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
 	if value, found := dm.cachedDependency(key); found {
 		return value.([]corev1.Secret), nil
 	}
-	secrets, err := dm.client.Secrets(namespace, selector)
+	secrets, err := dm.client.Secrets(namespace, selector, fieldSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -217,15 +485,180 @@ func (dm *DependencyManager) Secrets(namespace, selector string) ([]corev1.Secre
 	return secrets, nil
 }
 
-func (dm *DependencyManager) ServiceAccounts(namespace, selector string) ([]corev1.ServiceAccount, error) {
-	key := fmt.Sprintf("serviceaccounts(%s,%s)", namespace, selector)
+func (dm *DependencyManager) Secret(namespace, name string) (*corev1.Secret, error) {
+	key := dependencyKey{resource: "secrets", namespace: namespace, name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*corev1.Secret), nil
+	}
+	secret, err := dm.client.Secret(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		secret = nil
+	}
+	dm.cacheDependency(key, secret)
+	return secret, nil
+}
+
+func (dm *DependencyManager) ServiceAccounts(namespace, selector, fieldSelector string) ([]corev1.ServiceAccount, error) {
+	key := dependencyKey{resource: "serviceaccounts", namespace: namespace, selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
 	if value, found := dm.cachedDependency(key); found {
 		return value.([]corev1.ServiceAccount), nil
 	}
-	serviceaccounts, err := dm.client.ServiceAccounts(namespace, selector)
+	serviceaccounts, err := dm.client.ServiceAccounts(namespace, selector, fieldSelector)
 	if err != nil {
 		return nil, err
 	}
 	dm.cacheDependency(key, serviceaccounts)
 	return serviceaccounts, nil
 }
+
+func (dm *DependencyManager) ServiceAccount(namespace, name string) (*corev1.ServiceAccount, error) {
+	key := dependencyKey{resource: "serviceaccounts", namespace: namespace, name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*corev1.ServiceAccount), nil
+	}
+	serviceaccount, err := dm.client.ServiceAccount(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		serviceaccount = nil
+	}
+	dm.cacheDependency(key, serviceaccount)
+	return serviceaccount, nil
+}
+
+func (dm *DependencyManager) Deployments(namespace, selector, fieldSelector string) ([]appsv1.Deployment, error) {
+	key := dependencyKey{resource: "deployments", namespace: namespace, selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.([]appsv1.Deployment), nil
+	}
+	deployments, err := dm.client.Deployments(namespace, selector, fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	dm.cacheDependency(key, deployments)
+	return deployments, nil
+}
+
+func (dm *DependencyManager) Deployment(namespace, name string) (*appsv1.Deployment, error) {
+	key := dependencyKey{resource: "deployments", namespace: namespace, name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*appsv1.Deployment), nil
+	}
+	deployment, err := dm.client.Deployment(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		deployment = nil
+	}
+	dm.cacheDependency(key, deployment)
+	return deployment, nil
+}
+
+func (dm *DependencyManager) StatefulSets(namespace, selector, fieldSelector string) ([]appsv1.StatefulSet, error) {
+	key := dependencyKey{resource: "statefulsets", namespace: namespace, selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.([]appsv1.StatefulSet), nil
+	}
+	statefulsets, err := dm.client.StatefulSets(namespace, selector, fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	dm.cacheDependency(key, statefulsets)
+	return statefulsets, nil
+}
+
+func (dm *DependencyManager) StatefulSet(namespace, name string) (*appsv1.StatefulSet, error) {
+	key := dependencyKey{resource: "statefulsets", namespace: namespace, name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*appsv1.StatefulSet), nil
+	}
+	statefulset, err := dm.client.StatefulSet(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		statefulset = nil
+	}
+	dm.cacheDependency(key, statefulset)
+	return statefulset, nil
+}
+
+func (dm *DependencyManager) DaemonSets(namespace, selector, fieldSelector string) ([]appsv1.DaemonSet, error) {
+	key := dependencyKey{resource: "daemonsets", namespace: namespace, selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.([]appsv1.DaemonSet), nil
+	}
+	daemonsets, err := dm.client.DaemonSets(namespace, selector, fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	dm.cacheDependency(key, daemonsets)
+	return daemonsets, nil
+}
+
+func (dm *DependencyManager) DaemonSet(namespace, name string) (*appsv1.DaemonSet, error) {
+	key := dependencyKey{resource: "daemonsets", namespace: namespace, name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*appsv1.DaemonSet), nil
+	}
+	daemonset, err := dm.client.DaemonSet(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		daemonset = nil
+	}
+	dm.cacheDependency(key, daemonset)
+	return daemonset, nil
+}
+
+func (dm *DependencyManager) ReplicaSets(namespace, selector, fieldSelector string) ([]appsv1.ReplicaSet, error) {
+	key := dependencyKey{resource: "replicasets", namespace: namespace, selector: selector, fields: fieldSelector}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.([]appsv1.ReplicaSet), nil
+	}
+	replicasets, err := dm.client.ReplicaSets(namespace, selector, fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	dm.cacheDependency(key, replicasets)
+	return replicasets, nil
+}
+
+func (dm *DependencyManager) ReplicaSet(namespace, name string) (*appsv1.ReplicaSet, error) {
+	key := dependencyKey{resource: "replicasets", namespace: namespace, name: name}
+	dm.recordDependency(key)
+	if value, found := dm.cachedDependency(key); found {
+		return value.(*appsv1.ReplicaSet), nil
+	}
+	replicaset, err := dm.client.ReplicaSet(namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) || dm.strict {
+			return nil, err
+		}
+		// Missing object is not an error in non-strict mode
+		replicaset = nil
+	}
+	dm.cacheDependency(key, replicaset)
+	return replicaset, nil
+}
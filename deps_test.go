@@ -2,6 +2,7 @@ package main
 
 import (
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/kubernetes/pkg/controller/testutil"
 
@@ -15,21 +16,87 @@ func TestDependencyManager(t *testing.T) {
 	stopCh := make(chan struct{})
 	defer close(stopCh)
 
-	tc, err := newClient(fakeClient, stopCh, true)
+	tc, err := newClient(fakeClient, nil, stopCh, true)
 	require.NoError(t, err)
 
 	dm := newDependencyManager(tc)
 	require.Empty(t, dm.cachedDeps)
 
-	pods, err := dm.Pods("", "")
+	pods, err := dm.Pods("", "", "")
 	require.NoError(t, err)
 	require.Len(t, pods, 1)
 	require.NotEmpty(t, dm.cachedDeps)
 	pod1 := pods[0]
 	require.Equal(t, pod.Name, pod1.Name)
 
-	pods, err = dm.Pods("", "")
+	pods, err = dm.Pods("", "", "")
 	require.NoError(t, err)
 	require.Len(t, pods, 1)
 	require.Equal(t, pod1, pods[0])
 }
+
+func TestDependencyKeyAffectedBy(t *testing.T) {
+	key := dependencyKey{resource: "pods", namespace: "ns1", selector: "name=pod1"}
+	change := objectChange{resource: "pods", namespace: "ns1", labels: labels.Set{"name": "pod1"}}
+	require.True(t, key.affectedBy(change))
+
+	require.False(t, key.affectedBy(objectChange{resource: "services", namespace: "ns1", labels: labels.Set{"name": "pod1"}}))
+	require.False(t, key.affectedBy(objectChange{resource: "pods", namespace: "ns2", labels: labels.Set{"name": "pod1"}}))
+	require.False(t, key.affectedBy(objectChange{resource: "pods", namespace: "ns1", labels: labels.Set{"name": "pod2"}}))
+
+	// All namespaces and all objects
+	key = dependencyKey{resource: "pods"}
+	require.True(t, key.affectedBy(change))
+}
+
+func TestDependencyManagerRecording(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset()
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	tc, err := newClient(fakeClient, nil, stopCh, false)
+	require.NoError(t, err)
+
+	dm := newDependencyManager(tc)
+
+	_, err = dm.Pods("ns1", "", "")
+	require.NoError(t, err)
+
+	dm.startRecording()
+	_, err = dm.Pods("ns1", "", "")
+	require.NoError(t, err)
+	_, err = dm.Nodes("role=master", "")
+	require.NoError(t, err)
+	deps := dm.stopRecording()
+	require.Equal(t, map[dependencyKey]bool{
+		{resource: "pods", namespace: "ns1"}:         true,
+		{resource: "nodes", selector: "role=master"}: true,
+	}, deps)
+}
+
+func TestDependencyManagerMissingObject(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset()
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	tc, err := newClient(fakeClient, nil, stopCh, false)
+	require.NoError(t, err)
+
+	dm := newDependencyManager(tc)
+
+	node, err := dm.Node("node1")
+	require.NoError(t, err)
+	require.Nil(t, node)
+
+	dm.flushCachedDependencies()
+	dm.strict = true
+	_, err = dm.Node("node1")
+	require.Error(t, err)
+
+	// Missing object dependency is affected by object creation
+	key := dependencyKey{resource: "nodes", name: "node1"}
+	require.True(t, key.affectedBy(objectChange{resource: "nodes", name: "node1"}))
+	require.False(t, key.affectedBy(objectChange{resource: "nodes", name: "node2"}))
+}
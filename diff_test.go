@@ -0,0 +1,27 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	diff, err := unifiedDiff("a\nb\n", "a\nb\n", "old", "new")
+	require.NoError(t, err)
+	require.Empty(t, diff)
+
+	diff, err = unifiedDiff("a\nb\n", "a\nc\n", "old", "new")
+	require.NoError(t, err)
+	require.Equal(t, "--- old\n+++ new\n@@ -1,2 +1,2 @@\n a\n-b\n+c\n", diff)
+
+	require.Equal(t, "\x1b[1m--- old\x1b[0m\n\x1b[1m+++ new\x1b[0m\n\x1b[36m@@ -1,2 +1,2 @@\x1b[0m\n"+
+		" a\n\x1b[31m-b\x1b[0m\n\x1b[32m+c\x1b[0m\n", colorizeDiff(diff))
+}
+
+func TestSplitLines(t *testing.T) {
+	require.Empty(t, splitLines(""))
+	require.Equal(t, []string{"a\n", "b\n"}, splitLines("a\nb\n"))
+	require.Equal(t, []string{"a\n", "b\n"}, splitLines("a\nb"))
+	require.Equal(t, []string{"\n"}, splitLines("\n"))
+}
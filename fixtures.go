@@ -0,0 +1,250 @@
+// Copyright © 2015 Victor Antonovich <victor@antonovich.me>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// Kinds of well-known cluster-scoped objects, which are not placed to default namespace
+var clusterScopedKinds = []string{
+	"APIService",
+	"CertificateSigningRequest",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"ComponentStatus",
+	"CSIDriver",
+	"CSINode",
+	"CustomResourceDefinition",
+	"IngressClass",
+	"MutatingWebhookConfiguration",
+	"Namespace",
+	"Node",
+	"PersistentVolume",
+	"PodSecurityPolicy",
+	"PriorityClass",
+	"RuntimeClass",
+	"StorageClass",
+	"ValidatingWebhookConfiguration",
+	"VolumeAttachment",
+}
+
+// Kubernetes objects loaded from manifest files
+type Fixtures struct {
+	// Objects of types known to Kubernetes clientset
+	typed []runtime.Object
+	// All objects, as unstructured ones
+	unstructured []runtime.Object
+}
+
+// Load Kubernetes objects from YAML or JSON manifests (possibly multi-document ones) file
+// or directory. Namespaced objects of well-known types without namespace are placed to
+// given default namespace.
+func loadFixtures(path, defaultNamespace string) (*Fixtures, error) {
+	files, err := fixtureFiles(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fixtures := &Fixtures{}
+	keys := make(map[string]string)
+
+	for _, file := range files {
+		objs, err := readFixtureFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", file, err)
+		}
+		for _, u := range objs {
+			gvk := u.GroupVersionKind()
+			if gvk.Kind == "" || gvk.Version == "" {
+				return nil, fmt.Errorf("%s: object %q has no apiVersion or kind", file, u.GetName())
+			}
+			typed := scheme.Scheme.Recognizes(gvk)
+			if typed && u.GetNamespace() == "" && !IsPresent(clusterScopedKinds, gvk.Kind) {
+				u.SetNamespace(defaultNamespace)
+			}
+			// Fake clientsets can't hold duplicate objects
+			key := fmt.Sprintf("%s/%s/%s", gvk.GroupKind(), u.GetNamespace(), u.GetName())
+			if f, found := keys[key]; found {
+				return nil, fmt.Errorf("%s: duplicate object %s, already loaded from %s", file, key, f)
+			}
+			keys[key] = file
+			if typed {
+				obj, err := scheme.Scheme.New(gvk)
+				if err != nil {
+					return nil, err
+				}
+				if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj); err != nil {
+					return nil, fmt.Errorf("%s: can't convert %s: %v", file, key, err)
+				}
+				fixtures.typed = append(fixtures.typed, obj)
+			}
+			fixtures.unstructured = append(fixtures.unstructured, u)
+		}
+		glog.V(2).Infof("loaded %d object(s) from fixtures file: %s", len(objs), file)
+	}
+
+	return fixtures, nil
+}
+
+// Returns sorted list of manifest files at given path
+func fixtureFiles(path string) ([]string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return []string{path}, nil
+	}
+	var files []string
+	err = filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(p)) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Read Kubernetes objects from given manifests file, expanding lists
+func readFixtureFile(file string) ([]*unstructured.Unstructured, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer CloseQuietly(f)
+
+	var objs []*unstructured.Unstructured
+	decoder := utilyaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		var m map[string]interface{}
+		if err := decoder.Decode(&m); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		if len(m) == 0 {
+			// Empty document
+			continue
+		}
+		u := &unstructured.Unstructured{Object: m}
+		if u.IsList() {
+			l, err := u.ToList()
+			if err != nil {
+				return nil, err
+			}
+			for i := range l.Items {
+				objs = append(objs, &l.Items[i])
+			}
+			continue
+		}
+		objs = append(objs, u)
+	}
+
+	return objs, nil
+}
+
+// Create Kubernetes client serving objects from given fixtures
+func newClientForFixtures(fixtures *Fixtures, stopCh chan struct{}, useInformers bool) (*Client, error) {
+	kubeClient := fake.NewSimpleClientset(fixtures.typed...)
+	filterListsByFields(&kubeClient.Fake)
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), fixtures.unstructured...)
+	filterListsByFields(&dynamicClient.Fake)
+	return newClient(kubeClient, dynamicClient, stopCh, useInformers)
+}
+
+// Make fake client apply field selectors to listed objects, as fake clients ignore them.
+// Watches are not filtered, since fixture objects are never changed.
+func filterListsByFields(f *k8stesting.Fake) {
+	reactors := f.ReactionChain
+	f.PrependReactor("list", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		selector := action.(k8stesting.ListAction).GetListRestrictions().Fields
+		if selector == nil || selector.Empty() {
+			return false, nil, nil
+		}
+		for _, r := range reactors {
+			if !r.Handles(action) {
+				continue
+			}
+			handled, list, err := r.React(action)
+			if !handled {
+				continue
+			}
+			if err != nil {
+				return true, nil, err
+			}
+			return true, list, filterListByFields(list, selector)
+		}
+		return false, nil, nil
+	})
+}
+
+// Remove objects not matching given field selector from given list
+func filterListByFields(list runtime.Object, selector fields.Selector) error {
+	objs, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	var filtered []runtime.Object
+	for _, obj := range objs {
+		var content map[string]interface{}
+		if u, ok := obj.(*unstructured.Unstructured); ok {
+			content = u.Object
+		} else if content, err = runtime.DefaultUnstructuredConverter.ToUnstructured(obj); err != nil {
+			return err
+		}
+		// Missing fields are matched as empty ones
+		set := fields.Set{}
+		for _, r := range selector.Requirements() {
+			if v, found, _ := unstructured.NestedFieldNoCopy(content, strings.Split(r.Field, ".")...); found && v != nil {
+				set[r.Field] = fmt.Sprint(v)
+			} else {
+				set[r.Field] = ""
+			}
+		}
+		if selector.Matches(set) {
+			filtered = append(filtered, obj)
+		}
+	}
+	return meta.SetList(list, filtered)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func testFixturesName(t *testing.T) string {
+	return testDataFilePrefix(t) + ".fixtures.yaml"
+}
+
+func TestLoadFixtures(t *testing.T) {
+	fixtures, err := loadFixtures(testFixturesName(t), metav1.NamespaceDefault)
+	require.NoError(t, err)
+	require.Len(t, fixtures.typed, 4)
+	require.Len(t, fixtures.unstructured, 5)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	tc, err := newClientForFixtures(fixtures, stopCh, false)
+	require.NoError(t, err)
+
+	pods, err := tc.Pods(metav1.NamespaceDefault, "app=web", "")
+	require.NoError(t, err)
+	require.Len(t, pods, 1)
+	require.Equal(t, "host1", pods[0].Spec.NodeName)
+
+	namespaces, err := tc.Namespaces("", "")
+	require.NoError(t, err)
+	require.Len(t, namespaces, 1)
+	require.Equal(t, "", namespaces[0].Namespace)
+
+	services, err := tc.Services("frontend", "", "")
+	require.NoError(t, err)
+	require.Len(t, services, 2)
+	require.Equal(t, "10.0.0.2", services[1].Spec.ClusterIP)
+
+	gvr := schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+	certs, err := tc.Resources(gvr, "frontend", "", "")
+	require.NoError(t, err)
+	require.Len(t, certs, 1)
+	require.Equal(t, "cert1", certs[0].GetName())
+}
+
+func TestFixturesFieldSelector(t *testing.T) {
+	fixtures, err := loadFixtures(testFixturesName(t), metav1.NamespaceDefault)
+	require.NoError(t, err)
+
+	for _, useInformers := range []bool{false, true} {
+		stopCh := make(chan struct{})
+
+		tc, err := newClientForFixtures(fixtures, stopCh, useInformers)
+		require.NoError(t, err)
+
+		pods, err := tc.Pods(metav1.NamespaceDefault, "", "spec.nodeName=host2")
+		require.NoError(t, err)
+		require.Len(t, pods, 1, "informers: %v", useInformers)
+		require.Equal(t, "pod2", pods[0].Name)
+
+		pods, err = tc.Pods(metav1.NamespaceDefault, "", "spec.nodeName!=host2,metadata.name!=pod3")
+		require.NoError(t, err)
+		require.Len(t, pods, 1, "informers: %v", useInformers)
+		require.Equal(t, "pod1", pods[0].Name)
+
+		gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+		resources, err := tc.Resources(gvr, metav1.NamespaceDefault, "", "spec.nodeName=host1")
+		require.NoError(t, err)
+		require.Len(t, resources, 1, "informers: %v", useInformers)
+		require.Equal(t, "pod1", resources[0].GetName())
+
+		gvr = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+		resources, err = tc.Resources(gvr, "frontend", "", "spec.secretName=secret2")
+		require.NoError(t, err)
+		require.Empty(t, resources, "informers: %v", useInformers)
+
+		close(stopCh)
+	}
+}
+
+func TestLoadFixturesDuplicates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kube-template")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	pod := []byte("apiVersion: v1\nkind: Pod\nmetadata:\n  name: pod1\n")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "a.yaml"), pod, 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "b.txt"), pod, 0644))
+
+	fixtures, err := loadFixtures(dir, metav1.NamespaceDefault)
+	require.NoError(t, err)
+	require.Len(t, fixtures.typed, 1)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "c.json"),
+		[]byte(`{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "pod1", "namespace": "default"}}`), 0644))
+	_, err = loadFixtures(dir, metav1.NamespaceDefault)
+	require.Error(t, err)
+}
@@ -0,0 +1,108 @@
+// Copyright © 2015 Victor Antonovich <victor@antonovich.me>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/golang/glog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// Leader elector using Kubernetes lease
+type LeaderElector struct {
+	elector *leaderelection.LeaderElector
+	// Leading flag, accessed atomically
+	leading int32
+	// Leadership acquisition notification channel
+	leadingCh chan struct{}
+}
+
+func newLeaderElector(cfg LeaderElectionConfig, client kubernetes.Interface) (*LeaderElector, error) {
+	le := &LeaderElector{
+		leadingCh: make(chan struct{}, 1),
+	}
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      cfg.LeaseName,
+			Namespace: cfg.LeaseNamespace,
+		},
+		Client: client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: cfg.Identity,
+		},
+	}
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   cfg.LeaseDuration,
+		RenewDeadline:   cfg.RenewDeadline,
+		RetryPeriod:     cfg.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            cfg.LeaseName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(_ context.Context) {
+				glog.V(1).Infof("started leading as %s", cfg.Identity)
+				atomic.StoreInt32(&le.leading, 1)
+				select {
+				case le.leadingCh <- struct{}{}:
+				default:
+				}
+			},
+			OnStoppedLeading: func() {
+				if atomic.SwapInt32(&le.leading, 0) == 1 {
+					glog.V(1).Infof("stopped leading as %s", cfg.Identity)
+				}
+			},
+			OnNewLeader: func(identity string) {
+				if identity != cfg.Identity {
+					glog.V(1).Infof("current leader: %s", identity)
+				}
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	le.elector = elector
+	return le, nil
+}
+
+// Run leader election until given stop channel is closed, releasing lease on stop
+func (le *LeaderElector) Run(stopCh <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stopCh
+		cancel()
+	}()
+	// Try to acquire leadership again if lost
+	for ctx.Err() == nil {
+		le.elector.Run(ctx)
+	}
+}
+
+// Check leadership is held
+func (le *LeaderElector) IsLeader() bool {
+	return atomic.LoadInt32(&le.leading) == 1
+}
+
+// Returns channel to receive notifications about leadership acquisition
+func (le *LeaderElector) Leading() <-chan struct{} {
+	return le.leadingCh
+}
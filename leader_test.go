@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/kubernetes/pkg/controller/testutil"
+)
+
+// Create app with template 'pods.tmpl' from given dir and output '<identity>.txt' in same dir,
+// which is processed only while being leader
+func newTestLeaderApp(f *testFixture, dir, identity string) *App {
+	app := f.newApp(f.newTemplate("pods: {{len (pods)}}\n", &TemplateDescriptor{
+		Path:   filepath.Join(dir, "pods.tmpl"),
+		Output: filepath.Join(dir, identity+".txt"),
+	}))
+
+	leader, err := newLeaderElector(LeaderElectionConfig{
+		Enabled:        true,
+		LeaseName:      "kube-template",
+		LeaseNamespace: DefaultNamespace,
+		Identity:       identity,
+		LeaseDuration:  time.Second,
+		RenewDeadline:  500 * time.Millisecond,
+		RetryPeriod:    100 * time.Millisecond,
+	}, f.client.kubeClient)
+	require.NoError(f.t, err)
+	app.leader = leader
+
+	return app
+}
+
+func TestLeaderElection(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kube-template")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	kubeClient := fake.NewSimpleClientset(testutil.NewPod("pod1", "host1"))
+
+	apps := make(map[string]*App)
+	for _, identity := range []string{"a", "b"} {
+		f := newTestFixture(t, kubeClient, true)
+		defer f.Close()
+		app := newTestLeaderApp(f, dir, identity)
+		apps[identity] = app
+		go app.Start()
+	}
+	defer func() {
+		for _, app := range apps {
+			if app != nil {
+				app.Stop()
+				<-app.doneCh
+			}
+		}
+	}()
+
+	outputWritten := func(identity string) bool {
+		_, err := os.Stat(filepath.Join(dir, identity+".txt"))
+		return err == nil
+	}
+
+	// Only leader writes template output
+	require.Eventually(t, func() bool {
+		return outputWritten("a") || outputWritten("b")
+	}, 5*time.Second, 10*time.Millisecond)
+	leader, standby := "a", "b"
+	if outputWritten("b") {
+		leader, standby = "b", "a"
+	}
+	require.True(t, apps[leader].leader.IsLeader())
+	require.False(t, apps[standby].leader.IsLeader())
+	require.False(t, outputWritten(standby))
+
+	// Standby takes over leadership released by stopped leader
+	apps[leader].Stop()
+	<-apps[leader].doneCh
+	apps[leader] = nil
+	require.Eventually(t, func() bool {
+		return outputWritten(standby)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	actual, err := ioutil.ReadFile(filepath.Join(dir, standby+".txt"))
+	require.NoError(t, err)
+	require.Equal(t, "pods: 1\n", string(actual))
+
+	lease, err := kubeClient.CoordinationV1().Leases(DefaultNamespace).Get(context.TODO(), "kube-template", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, standby, *lease.Spec.HolderIdentity)
+}
+
+func TestLeaderElectionStandby(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kube-template")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	kubeClient := fake.NewSimpleClientset(testutil.NewPod("pod1", "host1"))
+
+	var lock sync.Mutex
+	// Numbers of run hook calls and templates updated, by app identity
+	runs, updates := make(map[string]int), make(map[string]int)
+
+	apps := make(map[string]*App)
+	for _, identity := range []string{"a", "b"} {
+		f := newTestFixture(t, kubeClient, true)
+		defer f.Close()
+		app := newTestLeaderApp(f, dir, identity)
+		identity := identity
+		app.onRun = func(app *App, updated []*Template) {
+			lock.Lock()
+			defer lock.Unlock()
+			runs[identity]++
+			updates[identity] += len(updated)
+		}
+		apps[identity] = app
+		go app.Start()
+	}
+	defer func() {
+		for _, app := range apps {
+			app.Stop()
+			<-app.doneCh
+		}
+	}()
+
+	// Both leader and standby are ready after templates are rendered
+	require.Eventually(t, func() bool {
+		return apps["a"].leader.IsLeader() != apps["b"].leader.IsLeader() &&
+			apps["a"].status().Ready && apps["b"].status().Ready
+	}, 5*time.Second, 10*time.Millisecond)
+	leader, standby := "a", "b"
+	if apps["b"].leader.IsLeader() {
+		leader, standby = "b", "a"
+	}
+
+	status := apps[standby].status()
+	require.False(t, *status.Leader)
+	require.Len(t, status.Templates, 1)
+	require.True(t, status.Templates[0].Rendered)
+	require.NotNil(t, status.Templates[0].LastRender)
+	require.Nil(t, status.Templates[0].LastChange)
+
+	// Run hook is called on standby without updated templates, so child process is started but not reloaded
+	require.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return runs[standby] > 0 && updates[leader] > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	lock.Lock()
+	defer lock.Unlock()
+	require.Equal(t, 0, updates[standby])
+}
@@ -0,0 +1,221 @@
+// Copyright © 2015 Victor Antonovich <victor@antonovich.me>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics name prefix
+const metricsNamespace = "kube_template"
+
+// Metric result label values
+const (
+	metricResultSuccess = "success"
+	metricResultError   = "error"
+)
+
+var (
+	renderDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "render_duration_seconds",
+		Help:      "Template processing duration by template and result.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"template", "result"})
+
+	outputChanges = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "output_changes_total",
+		Help:      "Number of template output changes.",
+	}, []string{"template"})
+
+	commandDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "command_duration_seconds",
+		Help:      "Command (or other action) execution duration.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"command"})
+
+	commandFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "command_failures_total",
+		Help:      "Number of failed command (or other action) executions.",
+	}, []string{"command"})
+
+	commandTimeouts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "command_timeouts_total",
+		Help:      "Number of timed out command executions.",
+	}, []string{"command"})
+
+	dependencyCacheHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "dependency_cache_hits_total",
+		Help:      "Number of Kubernetes objects requests served from dependency manager cache.",
+	})
+
+	dependencyCacheMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "dependency_cache_misses_total",
+		Help:      "Number of Kubernetes objects requests not found in dependency manager cache.",
+	})
+
+	kubeListDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "kube_list_duration_seconds",
+		Help:      "Kubernetes API server list requests latency by resource.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"resource"})
+
+	kubeListErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "kube_list_errors_total",
+		Help:      "Number of failed Kubernetes API server list requests by resource.",
+	}, []string{"resource"})
+
+	informersCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "informers",
+		Help:      "Number of running Kubernetes objects informers by namespace.",
+	}, []string{"namespace"})
+)
+
+// Registry of exposed metrics
+var metricsRegistry = prometheus.NewRegistry()
+
+func init() {
+	metricsRegistry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		renderDuration,
+		outputChanges,
+		commandDuration,
+		commandFailures,
+		commandTimeouts,
+		dependencyCacheHits,
+		dependencyCacheMisses,
+		kubeListDuration,
+		kubeListErrors,
+		informersCount,
+	)
+}
+
+// Returns HTTP handler exposing metrics in Prometheus format
+func metricsHandler() http.Handler {
+	return promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{})
+}
+
+// Returns metric result label value for given error
+func metricResult(err error) string {
+	if err != nil {
+		return metricResultError
+	}
+	return metricResultSuccess
+}
+
+// Observe template processing with given start time and result
+func observeRender(t *Template, started time.Time, updated bool, err error) {
+	renderDuration.WithLabelValues(t.name, metricResult(err)).Observe(time.Since(started).Seconds())
+	if updated {
+		outputChanges.WithLabelValues(t.name).Inc()
+	}
+}
+
+// Observe action execution with given start time and result
+func observeCommand(action Action, started time.Time, err error) {
+	command := action.String()
+	commandDuration.WithLabelValues(command).Observe(time.Since(started).Seconds())
+	if err == nil {
+		return
+	}
+	commandFailures.WithLabelValues(command).Inc()
+	var cmdErr *CommandError
+	if errors.As(err, &cmdErr) && cmdErr.TimedOut {
+		commandTimeouts.WithLabelValues(command).Inc()
+	}
+}
+
+// HTTP transport observing Kubernetes API server list requests
+type metricsRoundTripper struct {
+	rt http.RoundTripper
+}
+
+func newMetricsRoundTripper(rt http.RoundTripper) http.RoundTripper {
+	return &metricsRoundTripper{rt: rt}
+}
+
+func (m *metricsRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resource := listResource(req)
+	if resource == "" {
+		return m.rt.RoundTrip(req)
+	}
+	started := time.Now()
+	resp, err := m.rt.RoundTrip(req)
+	kubeListDuration.WithLabelValues(resource).Observe(time.Since(started).Seconds())
+	if err != nil || resp.StatusCode >= http.StatusBadRequest {
+		kubeListErrors.WithLabelValues(resource).Inc()
+	}
+	return resp, err
+}
+
+// Returns resource listed by given Kubernetes API server request in the format 'resource[.group]',
+// empty string if request is not a list one
+func listResource(req *http.Request) string {
+	if req.Method != http.MethodGet || req.URL.Query().Get("watch") == "true" {
+		return ""
+	}
+	path := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	var group string
+	switch {
+	case len(path) >= 2 && path[0] == "api":
+		path = path[2:]
+	case len(path) >= 3 && path[0] == "apis":
+		group, path = path[1], path[3:]
+	default:
+		return ""
+	}
+	if len(path) >= 3 && path[0] == "namespaces" {
+		path = path[2:]
+	}
+	if len(path) != 1 || path[0] == "" {
+		return ""
+	}
+	if group != "" {
+		return path[0] + "." + group
+	}
+	return path[0]
+}
+
+// Increase number of running informers in given namespace
+func informerStarted(namespace string) {
+	if namespace == "" {
+		namespace = "all"
+	}
+	informersCount.WithLabelValues(namespace).Inc()
+}
+
+// Decrease number of running informers in given namespace by given count
+func informersStopped(namespace string, count int) {
+	if namespace == "" {
+		namespace = "all"
+	}
+	informersCount.WithLabelValues(namespace).Sub(float64(count))
+}
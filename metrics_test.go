@@ -0,0 +1,95 @@
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+	kubetestutil "k8s.io/kubernetes/pkg/controller/testutil"
+)
+
+func TestListResource(t *testing.T) {
+	for path, expected := range map[string]string{
+		"/api/v1/pods":                                    "pods",
+		"/api/v1/namespaces":                              "namespaces",
+		"/api/v1/namespaces/default":                      "",
+		"/api/v1/namespaces/default/pods":                 "pods",
+		"/api/v1/namespaces/default/pods/nginx":           "",
+		"/apis/apps/v1/deployments":                       "deployments.apps",
+		"/apis/apps/v1/namespaces/default/deployments":    "deployments.apps",
+		"/apis/apps/v1/namespaces/default/deployments/nx": "",
+		"/apis":    "",
+		"/version": "",
+	} {
+		req, err := http.NewRequest(http.MethodGet, "http://localhost"+path, nil)
+		require.NoError(t, err)
+		require.Equal(t, expected, listResource(req), path)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost/api/v1/pods?watch=true", nil)
+	require.NoError(t, err)
+	require.Empty(t, listResource(req))
+}
+
+func TestMetricsRoundTripper(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/secrets") {
+			http.Error(w, "forbidden", http.StatusForbidden)
+		}
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: newMetricsRoundTripper(http.DefaultTransport)}
+	errors := testutil.ToFloat64(kubeListErrors.WithLabelValues("secrets"))
+	for _, path := range []string{"/api/v1/configmaps", "/api/v1/namespaces/default/secrets"} {
+		resp, err := client.Get(srv.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	require.Equal(t, errors+1, testutil.ToFloat64(kubeListErrors.WithLabelValues("secrets")))
+	require.Equal(t, float64(0), testutil.ToFloat64(kubeListErrors.WithLabelValues("configmaps")))
+}
+
+func TestMetrics(t *testing.T) {
+	f := newTestFixture(t, fake.NewSimpleClientset(kubetestutil.NewPod("pod1", "host1")), true)
+	defer f.Close()
+
+	app := f.newApp(
+		f.newTemplate("pods: {{len (pods)}}", &TemplateDescriptor{Path: "metrics0.tmpl"}),
+		f.newTemplate("pods: {{len (pods)}}", &TemplateDescriptor{Path: "metrics1.tmpl", Command: "false", OnFailure: OnFailureIgnore}),
+	)
+
+	// Metrics are shared between tests, so their changes are checked
+	hits, misses := testutil.ToFloat64(dependencyCacheHits), testutil.ToFloat64(dependencyCacheMisses)
+	failures := testutil.ToFloat64(commandFailures.WithLabelValues("false"))
+	informers := testutil.ToFloat64(informersCount.WithLabelValues(DefaultNamespace))
+
+	require.NoError(t, app.RunOnce())
+
+	require.Equal(t, float64(1), testutil.ToFloat64(outputChanges.WithLabelValues("metrics0.tmpl")))
+	require.Equal(t, failures+1, testutil.ToFloat64(commandFailures.WithLabelValues("false")))
+	require.Equal(t, hits+1, testutil.ToFloat64(dependencyCacheHits))
+	require.Equal(t, misses+1, testutil.ToFloat64(dependencyCacheMisses))
+	require.Equal(t, informers+1, testutil.ToFloat64(informersCount.WithLabelValues(DefaultNamespace)))
+
+	srv := httptest.NewServer(newServer(0).handler)
+	defer srv.Close()
+	resp, err := http.Get(srv.URL + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), `kube_template_render_duration_seconds_count{result="success",template="metrics0.tmpl"} 1`)
+
+	// Informers are stopped with client
+	close(f.stopCh)
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(informersCount.WithLabelValues(DefaultNamespace)) == informers
+	}, 5*time.Second, 10*time.Millisecond)
+}
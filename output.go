@@ -0,0 +1,258 @@
+// Copyright © 2015 Victor Antonovich <victor@antonovich.me>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// Kubernetes object template output schemes
+const (
+	OutputSchemeConfigMap = "configmap"
+	OutputSchemeSecret    = "secret"
+)
+
+// Field manager name and ownership labels of Kubernetes objects written by templates
+const (
+	FieldManager      = "kube-template"
+	LabelManagedBy    = "app.kubernetes.io/managed-by"
+	LabelManagedByApp = "kube-template"
+)
+
+// Template output key in Kubernetes ConfigMap or Secret
+type kubeOutput struct {
+	client kubernetes.Interface
+	// Output scheme: ConfigMap or Secret
+	scheme string
+	// Object namespace, name and data key
+	namespace string
+	name      string
+	key       string
+	// Object was created by last write
+	created bool
+}
+
+// Check template output is Kubernetes object one
+func isKubeOutput(output string) bool {
+	return strings.HasPrefix(output, OutputSchemeConfigMap+"://") ||
+		strings.HasPrefix(output, OutputSchemeSecret+"://")
+}
+
+// Parse template output in the format 'configmap://namespace/name/key' or 'secret://namespace/name/key'
+func newKubeOutput(client kubernetes.Interface, output string) (*kubeOutput, error) {
+	scheme, namespace, name, key, err := parseObjectKeyURL(output, OutputSchemeConfigMap, OutputSchemeSecret)
+	if err != nil {
+		return nil, err
+	}
+	return &kubeOutput{
+		client:    client,
+		scheme:    scheme,
+		namespace: namespace,
+		name:      name,
+		key:       key,
+	}, nil
+}
+
+// Parse Kubernetes object data key URL in the format 'scheme://namespace/name/key' with one of given schemes
+func parseObjectKeyURL(s string, schemes ...string) (scheme, namespace, name, key string, err error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", "", "", "", err
+	}
+	if !IsPresent(schemes, u.Scheme) {
+		return "", "", "", "", fmt.Errorf("unsupported scheme %q, expected one of %v", u.Scheme, schemes)
+	}
+	parts := strings.Split(strings.TrimPrefix(u.Path, "/"), "/")
+	if u.Host == "" || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", "", fmt.Errorf("invalid %q, should be '%s://namespace/name/key'", s, u.Scheme)
+	}
+	if errs := validation.IsConfigMapKey(parts[1]); len(errs) > 0 {
+		return "", "", "", "", fmt.Errorf("invalid key %q: %s", parts[1], strings.Join(errs, ", "))
+	}
+	return u.Scheme, u.Host, parts[0], parts[1], nil
+}
+
+func (o *kubeOutput) String() string {
+	return fmt.Sprintf("%s://%s/%s/%s", o.scheme, o.namespace, o.name, o.key)
+}
+
+// Read output contents, returns false if object or its key is not present
+func (o *kubeOutput) Read() ([]byte, bool, error) {
+	var data map[string][]byte
+	err := o.get(func(_ metav1.Object, d map[string][]byte) (bool, error) {
+		data = d
+		return false, nil
+	})
+	if apierrors.IsNotFound(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	content, found := data[o.key]
+	return content, found, nil
+}
+
+// Write output contents, creating object if not present.
+// Existing object is updated only if it is labeled as managed by kube-template.
+func (o *kubeOutput) Write(content []byte) error {
+	o.created = false
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := o.get(func(obj metav1.Object, data map[string][]byte) (bool, error) {
+			if err := o.checkManaged(obj.GetLabels()); err != nil {
+				return false, err
+			}
+			data[o.key] = content
+			return true, nil
+		})
+		if apierrors.IsNotFound(err) {
+			return o.create(content)
+		}
+		return err
+	})
+}
+
+// Remove output key from object, if present and managed by kube-template.
+// Object created by last write is deleted if it has no other keys.
+func (o *kubeOutput) Remove() error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var created metav1.Object
+		err := o.get(func(obj metav1.Object, data map[string][]byte) (bool, error) {
+			if _, found := data[o.key]; !found {
+				return false, nil
+			}
+			if err := o.checkManaged(obj.GetLabels()); err != nil {
+				return false, err
+			}
+			delete(data, o.key)
+			if o.created && len(data) == 0 {
+				created = obj
+				return false, nil
+			}
+			return true, nil
+		})
+		if err == nil && created != nil {
+			err = o.delete(created)
+		}
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+}
+
+// Check object with given labels is managed by kube-template, so it can be modified
+func (o *kubeOutput) checkManaged(labels map[string]string) error {
+	if labels[LabelManagedBy] != LabelManagedByApp {
+		return fmt.Errorf("%s %s/%s exists and is not managed by kube-template (missing label %s=%s)",
+			o.scheme, o.namespace, o.name, LabelManagedBy, LabelManagedByApp)
+	}
+	return nil
+}
+
+// Get object and call given function with its metadata and data to modify,
+// updating object if function returns true
+func (o *kubeOutput) get(modify func(obj metav1.Object, data map[string][]byte) (bool, error)) error {
+	ctx := context.TODO()
+	updateOptions := metav1.UpdateOptions{FieldManager: FieldManager}
+	switch o.scheme {
+	case OutputSchemeConfigMap:
+		cm, err := o.client.CoreV1().ConfigMaps(o.namespace).Get(ctx, o.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if cm.Labels == nil {
+			cm.Labels = make(map[string]string)
+		}
+		data := make(map[string][]byte, len(cm.Data))
+		for k, v := range cm.Data {
+			data[k] = []byte(v)
+		}
+		if update, err := modify(cm, data); !update || err != nil {
+			return err
+		}
+		cm.Data = make(map[string]string, len(data))
+		for k, v := range data {
+			cm.Data[k] = string(v)
+		}
+		_, err = o.client.CoreV1().ConfigMaps(o.namespace).Update(ctx, cm, updateOptions)
+		return err
+	default:
+		secret, err := o.client.CoreV1().Secrets(o.namespace).Get(ctx, o.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if secret.Labels == nil {
+			secret.Labels = make(map[string]string)
+		}
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		if update, err := modify(secret, secret.Data); !update || err != nil {
+			return err
+		}
+		_, err = o.client.CoreV1().Secrets(o.namespace).Update(ctx, secret, updateOptions)
+		return err
+	}
+}
+
+// Create object with given output contents
+func (o *kubeOutput) create(content []byte) error {
+	ctx := context.TODO()
+	meta := metav1.ObjectMeta{
+		Name:      o.name,
+		Namespace: o.namespace,
+		Labels:    map[string]string{LabelManagedBy: LabelManagedByApp},
+	}
+	createOptions := metav1.CreateOptions{FieldManager: FieldManager}
+	var err error
+	switch o.scheme {
+	case OutputSchemeConfigMap:
+		cm := &corev1.ConfigMap{ObjectMeta: meta, Data: map[string]string{o.key: string(content)}}
+		_, err = o.client.CoreV1().ConfigMaps(o.namespace).Create(ctx, cm, createOptions)
+	default:
+		secret := &corev1.Secret{ObjectMeta: meta, Data: map[string][]byte{o.key: content}}
+		_, err = o.client.CoreV1().Secrets(o.namespace).Create(ctx, secret, createOptions)
+	}
+	o.created = err == nil
+	return err
+}
+
+// Delete given object, if not changed since it was read
+func (o *kubeOutput) delete(obj metav1.Object) error {
+	uid, resourceVersion := obj.GetUID(), obj.GetResourceVersion()
+	options := metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid, ResourceVersion: &resourceVersion}}
+	var err error
+	switch o.scheme {
+	case OutputSchemeConfigMap:
+		err = o.client.CoreV1().ConfigMaps(o.namespace).Delete(context.TODO(), o.name, options)
+	default:
+		err = o.client.CoreV1().Secrets(o.namespace).Delete(context.TODO(), o.name, options)
+	}
+	if err == nil {
+		o.created = false
+	}
+	return err
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/kubernetes/pkg/controller/testutil"
+)
+
+func TestNewKubeOutput(t *testing.T) {
+	o, err := newKubeOutput(nil, "configmap://ns1/nginx/nginx.conf")
+	require.NoError(t, err)
+	require.Equal(t, &kubeOutput{scheme: OutputSchemeConfigMap, namespace: "ns1", name: "nginx", key: "nginx.conf"}, o)
+	require.Equal(t, "configmap://ns1/nginx/nginx.conf", o.String())
+
+	o, err = newKubeOutput(nil, "secret://ns1/nginx/tls.key")
+	require.NoError(t, err)
+	require.Equal(t, &kubeOutput{scheme: OutputSchemeSecret, namespace: "ns1", name: "nginx", key: "tls.key"}, o)
+
+	for _, output := range []string{
+		"configmap://ns1/nginx",
+		"configmap:///nginx/nginx.conf",
+		"configmap://ns1/nginx/conf/nginx.conf",
+		"secret://ns1/nginx/tls:key",
+		"file://ns1/nginx/nginx.conf",
+	} {
+		_, err := newKubeOutput(nil, output)
+		require.Error(t, err, output)
+	}
+}
+
+func TestTemplateKubeOutput(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset(testutil.NewPod("pod1", "host1"),
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "pods", Namespace: DefaultNamespace,
+				Labels: map[string]string{LabelManagedBy: LabelManagedByApp}},
+			Data: map[string][]byte{"pods.txt": []byte("pods: 1\n"), "other": []byte("other")},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "unmanaged", Namespace: DefaultNamespace},
+			Data:       map[string]string{"other": "other"},
+		})
+
+	f := newTestFixture(t, fakeClient, false)
+	defer f.Close()
+
+	content := "pods: {{len (pods)}}\n"
+	ctx := context.TODO()
+
+	// Create new ConfigMap
+	tmpl := f.newTemplate(content, &TemplateDescriptor{Path: "pods.tmpl", Output: "configmap://default/pods/pods.txt"})
+	require.Empty(t, tmpl.lastOutput)
+	updated, err := tmpl.Process(false)
+	require.NoError(t, err)
+	require.True(t, updated)
+	cm, err := fakeClient.CoreV1().ConfigMaps(DefaultNamespace).Get(ctx, "pods", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"pods.txt": "pods: 1\n"}, cm.Data)
+	require.Equal(t, LabelManagedByApp, cm.Labels[LabelManagedBy])
+
+	// Roll back deletes created object without other keys
+	require.NoError(t, tmpl.Rollback())
+	_, err = fakeClient.CoreV1().ConfigMaps(DefaultNamespace).Get(ctx, "pods", metav1.GetOptions{})
+	require.True(t, apierrors.IsNotFound(err))
+
+	// Roll back removes key written from created object with other keys
+	tmpl = f.newTemplate(content, &TemplateDescriptor{Path: "pods.tmpl", Output: "configmap://default/shared/pods.txt"})
+	require.NoError(t, tmpl.Write([]byte("pods: 1\n")))
+	cm, err = fakeClient.CoreV1().ConfigMaps(DefaultNamespace).Get(ctx, "shared", metav1.GetOptions{})
+	require.NoError(t, err)
+	cm.Data["other"] = "other"
+	_, err = fakeClient.CoreV1().ConfigMaps(DefaultNamespace).Update(ctx, cm, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, tmpl.Rollback())
+	cm, err = fakeClient.CoreV1().ConfigMaps(DefaultNamespace).Get(ctx, "shared", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"other": "other"}, cm.Data)
+
+	// Last output is read from existing Secret
+	tmpl = f.newTemplate(content, &TemplateDescriptor{Path: "pods.tmpl", Output: "secret://default/pods/pods.txt"})
+	require.Equal(t, "pods: 1\n", tmpl.lastOutput)
+	updated, err = tmpl.Process(false)
+	require.NoError(t, err)
+	require.False(t, updated)
+
+	// Update existing Secret key, keeping other ones
+	require.NoError(t, tmpl.Write([]byte("pods: 2\n")))
+	secret, err := fakeClient.CoreV1().Secrets(DefaultNamespace).Get(ctx, "pods", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"pods.txt": []byte("pods: 2\n"), "other": []byte("other")}, secret.Data)
+	require.Equal(t, LabelManagedByApp, secret.Labels[LabelManagedBy])
+
+	// Roll back restores previous value
+	require.NoError(t, tmpl.Rollback())
+	secret, err = fakeClient.CoreV1().Secrets(DefaultNamespace).Get(ctx, "pods", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []byte("pods: 1\n"), secret.Data["pods.txt"])
+
+	// Existing object not managed by kube-template is not updated
+	tmpl = f.newTemplate(content, &TemplateDescriptor{Path: "pods.tmpl", Output: "configmap://default/unmanaged/pods.txt"})
+	_, err = tmpl.Process(false)
+	require.EqualError(t, err, "can't write template pods.tmpl output: configmap default/unmanaged exists and is not managed by "+
+		"kube-template (missing label app.kubernetes.io/managed-by=kube-template)")
+	cm, err = fakeClient.CoreV1().ConfigMaps(DefaultNamespace).Get(ctx, "unmanaged", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"other": "other"}, cm.Data)
+
+	// Output file options can't be used
+	_, err = newTemplate(&Config{LeftDelimiter: "{{", RightDelimiter: "}}"}, f.dm,
+		&TemplateDescriptor{Path: filepath.Join(f.dir, "pods.tmpl"), Output: "secret://default/pods/pods.txt", Perms: 0600})
+	require.Error(t, err)
+}
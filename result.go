@@ -0,0 +1,198 @@
+// Copyright © 2015 Victor Antonovich <victor@antonovich.me>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Template processing error classes
+const (
+	// Template couldn't be rendered
+	ErrorClassRender = "render"
+	// Template output couldn't be written
+	ErrorClassWrite = "write"
+	// Command (or other action) executed after template output updating failed
+	ErrorClassCommand = "command"
+)
+
+// Exit codes of run once mode for error classes, in order of precedence
+var errorClassExitCodes = []struct {
+	class    string
+	exitCode int
+}{
+	{ErrorClassRender, ExitCodeRenderFailed},
+	{ErrorClassWrite, ExitCodeWriteFailed},
+	{ErrorClassCommand, ExitCodeCommandFailed},
+}
+
+// Template processing statuses
+const (
+	TemplateStatusUpdated   = "updated"
+	TemplateStatusUnchanged = "unchanged"
+	TemplateStatusFailed    = "failed"
+)
+
+// Template processing error
+type TemplateError struct {
+	// Template name
+	Template string
+	// Error class
+	Class string
+	// Error occurred
+	Err error
+}
+
+func newTemplateError(t *Template, class string, err error) *TemplateError {
+	return &TemplateError{Template: t.name, Class: class, Err: err}
+}
+
+func (e *TemplateError) Error() string {
+	switch e.Class {
+	case ErrorClassRender:
+		return fmt.Sprintf("can't render template %s: %v", e.Template, e.Err)
+	case ErrorClassWrite:
+		return fmt.Sprintf("can't write template %s output: %v", e.Template, e.Err)
+	default:
+		return fmt.Sprintf("template %s %s failed: %v", e.Template, e.Class, e.Err)
+	}
+}
+
+func (e *TemplateError) Unwrap() error {
+	return e.Err
+}
+
+func (e *TemplateError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Class string `json:"class"`
+		Error string `json:"error"`
+	}{e.Class, e.Err.Error()})
+}
+
+// Aggregated error of templates processing run
+type RunError struct {
+	Errors []*TemplateError
+}
+
+func (e *RunError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d error(s) occurred: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Check any of errors is of given class
+func (e *RunError) HasClass(class string) bool {
+	for _, err := range e.Errors {
+		if err.Class == class {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns run once mode exit code for most significant error class
+func (e *RunError) ExitCode() int {
+	for _, c := range errorClassExitCodes {
+		if e.HasClass(c.class) {
+			return c.exitCode
+		}
+	}
+	return ExitCodeCommandFailed
+}
+
+// Template processing result
+type TemplateResult struct {
+	Template string           `json:"template"`
+	Path     string           `json:"path"`
+	Output   string           `json:"output"`
+	Status   string           `json:"status"`
+	Errors   []*TemplateError `json:"errors,omitempty"`
+
+	t *Template
+}
+
+// Templates processing run summary
+type RunSummary struct {
+	Templates []*TemplateResult `json:"templates"`
+	Error     string            `json:"error,omitempty"`
+	ExitCode  int               `json:"exitCode"`
+}
+
+// Record error of given template processing, rendering error if not classified
+func (app *App) addError(t *Template, err error) {
+	tErr, ok := err.(*TemplateError)
+	if !ok {
+		tErr = newTemplateError(t, ErrorClassRender, err)
+	}
+	app.errors = append(app.errors, tErr)
+	if r := app.result(t); r != nil {
+		r.Status = TemplateStatusFailed
+		r.Errors = append(r.Errors, tErr)
+	}
+}
+
+// Returns result of given template processing during last run, if processed
+func (app *App) result(t *Template) *TemplateResult {
+	for _, r := range app.results {
+		if r.t == t {
+			return r
+		}
+	}
+	return nil
+}
+
+// Returns aggregated error of last run, nil if no errors occurred
+func (app *App) err() error {
+	if len(app.errors) == 0 {
+		return nil
+	}
+	return &RunError{Errors: app.errors}
+}
+
+// Returns summary of last run with given error
+func (app *App) summary(err error) *RunSummary {
+	s := &RunSummary{Templates: app.results}
+	if s.Templates == nil {
+		s.Templates = []*TemplateResult{}
+	}
+	if err != nil {
+		s.Error = err.Error()
+		s.ExitCode = runExitCode(err)
+	}
+	return s
+}
+
+// Print summary of last run with given error in JSON format
+func (app *App) printSummary(w io.Writer, err error) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(app.summary(err))
+}
+
+// Returns run once mode exit code for given run error
+func runExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if runErr, ok := err.(*RunError); ok {
+		return runErr.ExitCode()
+	}
+	return ExitCodeCommandFailed
+}
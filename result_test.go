@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestAppRunErrors(t *testing.T) {
+	f := newTestFixture(t, fake.NewSimpleClientset(), false)
+	defer f.Close()
+
+	// Output path can't be created under regular file
+	notDir := filepath.Join(f.dir, "file")
+	require.NoError(t, ioutil.WriteFile(notDir, nil, 0644))
+
+	app := f.newApp(
+		f.newTemplate("ok", &TemplateDescriptor{}),
+		f.newTemplate(`{{template "missing"}}`, &TemplateDescriptor{}),
+		f.newTemplate("ok", &TemplateDescriptor{Output: filepath.Join(notDir, "out.txt")}),
+		f.newTemplate("ok", &TemplateDescriptor{Command: "false"}),
+	)
+	app.runOnce = true
+
+	err := app.RunOnce()
+	require.IsType(t, &RunError{}, err)
+	runErr := err.(*RunError)
+	require.Len(t, runErr.Errors, 3)
+	require.True(t, runErr.HasClass(ErrorClassRender))
+	require.True(t, runErr.HasClass(ErrorClassWrite))
+	require.True(t, runErr.HasClass(ErrorClassCommand))
+	require.Equal(t, ExitCodeRenderFailed, runExitCode(err))
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, app.printSummary(buf, err))
+	var summary struct {
+		Templates []struct {
+			Template string
+			Status   string
+			Errors   []struct {
+				Class string
+			}
+		}
+		ExitCode int
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &summary))
+	require.Equal(t, ExitCodeRenderFailed, summary.ExitCode)
+	require.Len(t, summary.Templates, 4)
+	for i, expected := range []struct{ status, class string }{
+		{TemplateStatusUpdated, ""},
+		{TemplateStatusFailed, ErrorClassRender},
+		{TemplateStatusFailed, ErrorClassWrite},
+		{TemplateStatusFailed, ErrorClassCommand},
+	} {
+		r := summary.Templates[i]
+		require.Equal(t, fmt.Sprintf("%d.tmpl", i), r.Template)
+		require.Equal(t, expected.status, r.Status)
+		if expected.class != "" {
+			require.Len(t, r.Errors, 1)
+			require.Equal(t, expected.class, r.Errors[0].Class)
+		} else {
+			require.Empty(t, r.Errors)
+		}
+	}
+
+	// Only command failed
+	app.templates = []*Template{f.newTemplate("ok", &TemplateDescriptor{Command: "false"})}
+	err = app.RunOnce()
+	require.Error(t, err)
+	require.Equal(t, ExitCodeCommandFailed, runExitCode(err))
+
+	// No errors
+	app.templates = []*Template{f.newTemplate("ok", &TemplateDescriptor{Command: "true"})}
+	err = app.RunOnce()
+	require.NoError(t, err)
+	require.Equal(t, 0, runExitCode(err))
+}
@@ -0,0 +1,241 @@
+// Copyright © 2015 Victor Antonovich <victor@antonovich.me>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagListen        = "listen"
+	FlagHealthTimeout = "health-timeout"
+)
+
+// Template processing status, for status endpoint
+type TemplateStatus struct {
+	Template string `json:"template"`
+	Path     string `json:"path"`
+	Output   string `json:"output"`
+	// Template was successfully processed at least once
+	Rendered bool `json:"rendered"`
+	// Time of last template processing
+	LastRender *time.Time `json:"lastRender,omitempty"`
+	// Time of last template output change
+	LastChange *time.Time `json:"lastChange,omitempty"`
+	// Error of last template processing and its time, cleared on successful processing
+	LastError     string     `json:"lastError,omitempty"`
+	LastErrorTime *time.Time `json:"lastErrorTime,omitempty"`
+	// Result of last command (or other action) executed after template output updating
+	LastCommand *CommandStatus `json:"lastCommand,omitempty"`
+	// SHA-256 checksum of last template output, in hex
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// Command (or other action) execution result, for status endpoint
+type CommandStatus struct {
+	Command string    `json:"command"`
+	Time    time.Time `json:"time"`
+	Error   string    `json:"error,omitempty"`
+	// Command exit code, nil if unknown (command not exited or not a command)
+	ExitCode *int `json:"exitCode,omitempty"`
+}
+
+// Templates processing status, for status endpoint
+type AppStatus struct {
+	Ready bool `json:"ready"`
+	// Leadership is held, nil if leader election is disabled
+	Leader *bool `json:"leader,omitempty"`
+	// Start time of templates processing run in progress, if any
+	RunStarted *time.Time        `json:"runStarted,omitempty"`
+	Templates  []*TemplateStatus `json:"templates"`
+}
+
+// Update processing status of given template with given function
+func (app *App) updateStatus(t *Template, update func(s *TemplateStatus)) {
+	app.statusLock.Lock()
+	defer app.statusLock.Unlock()
+	update(&t.status)
+}
+
+// Record processing result of given template at given time
+func (app *App) processed(t *Template, now time.Time, updated bool, err error) {
+	app.updateStatus(t, func(s *TemplateStatus) {
+		s.LastRender = &now
+		if err != nil {
+			s.LastError, s.LastErrorTime = err.Error(), &now
+			return
+		}
+		s.Rendered = true
+		s.LastError, s.LastErrorTime = "", nil
+		if updated {
+			s.LastChange = &now
+		}
+		checksum := sha256.Sum256([]byte(t.lastOutput))
+		s.Checksum = hex.EncodeToString(checksum[:])
+	})
+}
+
+// Record result of action executed after given templates outputs updating
+func (app *App) executed(templates []*Template, action Action, err error) {
+	cs := &CommandStatus{Command: action.String(), Time: time.Now()}
+	if err != nil {
+		cs.Error = err.Error()
+	}
+	var cmdErr *CommandError
+	if errors.As(err, &cmdErr) {
+		if cmdErr.Signal == nil {
+			cs.ExitCode = &cmdErr.ExitCode
+		}
+	} else if _, ok := action.(Command); ok && err == nil {
+		exitCode := 0
+		cs.ExitCode = &exitCode
+	}
+	for _, t := range templates {
+		app.updateStatus(t, func(s *TemplateStatus) {
+			s.LastCommand = cs
+		})
+	}
+}
+
+// Returns current templates processing status
+func (app *App) status() *AppStatus {
+	app.statusLock.RLock()
+	defer app.statusLock.RUnlock()
+	status := &AppStatus{Ready: true, Templates: make([]*TemplateStatus, 0, len(app.templates))}
+	if !app.runStarted.IsZero() {
+		runStarted := app.runStarted
+		status.RunStarted = &runStarted
+	}
+	if app.leader != nil {
+		leader := app.leader.IsLeader()
+		status.Leader = &leader
+	}
+	for _, t := range app.templates {
+		s := t.status
+		s.Template, s.Path, s.Output = t.name, t.desc.Path, t.desc.Output
+		status.Ready = status.Ready && s.Rendered
+		status.Templates = append(status.Templates, &s)
+	}
+	return status
+}
+
+// HTTP server exposing health, readiness and status endpoints of current app
+type Server struct {
+	sync.RWMutex
+
+	// Current app, replaced on config reloading
+	app *App
+	// Maximum duration of templates processing run to consider app healthy
+	healthTimeout time.Duration
+
+	handler *http.ServeMux
+}
+
+func newServer(healthTimeout time.Duration) *Server {
+	s := &Server{
+		healthTimeout: healthTimeout,
+		handler:       http.NewServeMux(),
+	}
+	s.handler.HandleFunc("/healthz", s.healthz)
+	s.handler.HandleFunc("/readyz", s.readyz)
+	s.handler.HandleFunc("/status", s.status)
+	s.handler.Handle("/metrics", metricsHandler())
+	return s
+}
+
+// Start HTTP server listening on given address if set, returns nil server otherwise
+func startServer(cmd *cobra.Command) (*Server, error) {
+	addr, _ := cmd.Flags().GetString(FlagListen)
+	if addr == "" {
+		return nil, nil
+	}
+	healthTimeout, _ := cmd.Flags().GetDuration(FlagHealthTimeout)
+	s := newServer(healthTimeout)
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	glog.V(1).Infof("listening on %s", l.Addr())
+	go func() {
+		if err := http.Serve(l, s.handler); err != nil {
+			glog.Errorf("HTTP server error: %v", err)
+		}
+	}()
+	return s, nil
+}
+
+// Set current app, if server is running
+func (s *Server) setApp(app *App) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.app = app
+}
+
+// Returns current app, nil if not set yet
+func (s *Server) currentApp() *App {
+	s.RLock()
+	defer s.RUnlock()
+	return s.app
+}
+
+// Liveness endpoint: fails if templates processing run is in progress for too long
+func (s *Server) healthz(w http.ResponseWriter, _ *http.Request) {
+	if app := s.currentApp(); app != nil && s.healthTimeout > 0 {
+		if started := app.status().RunStarted; started != nil && time.Since(*started) > s.healthTimeout {
+			http.Error(w, fmt.Sprintf("templates processing in progress since %v", started.Format(time.RFC3339)),
+				http.StatusServiceUnavailable)
+			return
+		}
+	}
+	fmt.Fprintln(w, "ok")
+}
+
+// Readiness endpoint: fails until all templates are rendered at least once
+func (s *Server) readyz(w http.ResponseWriter, _ *http.Request) {
+	app := s.currentApp()
+	if app == nil || !app.status().Ready {
+		http.Error(w, "not all templates are rendered yet", http.StatusServiceUnavailable)
+		return
+	}
+	fmt.Fprintln(w, "ok")
+}
+
+// Status endpoint: templates processing status in JSON format
+func (s *Server) status(w http.ResponseWriter, _ *http.Request) {
+	status := &AppStatus{Templates: []*TemplateStatus{}}
+	if app := s.currentApp(); app != nil {
+		status = app.status()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(status); err != nil {
+		glog.Errorf("can't write status: %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestServer(t *testing.T) {
+	f := newTestFixture(t, fake.NewSimpleClientset(), false)
+	defer f.Close()
+
+	s := newServer(time.Minute)
+	srv := httptest.NewServer(s.handler)
+	defer srv.Close()
+
+	get := func(path string) (int, string) {
+		resp, err := http.Get(srv.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	// No app yet
+	code, _ := get("/healthz")
+	require.Equal(t, http.StatusOK, code)
+	code, _ = get("/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+
+	app := newTestCommandApp(f, &TemplateDescriptor{Command: "exit 3", OnFailure: OnFailureIgnore})
+	s.setApp(app)
+
+	code, _ = get("/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+
+	app.RunOnce()
+
+	code, _ = get("/readyz")
+	require.Equal(t, http.StatusOK, code)
+
+	code, body := get("/status")
+	require.Equal(t, http.StatusOK, code)
+	var status AppStatus
+	require.NoError(t, json.Unmarshal([]byte(body), &status))
+	require.True(t, status.Ready)
+	require.Nil(t, status.RunStarted)
+	require.Len(t, status.Templates, 1)
+	ts := status.Templates[0]
+	require.Equal(t, "in.tmpl", ts.Template)
+	require.True(t, ts.Rendered)
+	require.NotNil(t, ts.LastRender)
+	require.NotNil(t, ts.LastChange)
+	require.Empty(t, ts.LastError)
+	checksum := sha256.Sum256([]byte("new"))
+	require.Equal(t, hex.EncodeToString(checksum[:]), ts.Checksum)
+	require.NotNil(t, ts.LastCommand)
+	require.Equal(t, "exit 3", ts.LastCommand.Command)
+	require.NotEmpty(t, ts.LastCommand.Error)
+	require.NotNil(t, ts.LastCommand.ExitCode)
+	require.Equal(t, 3, *ts.LastCommand.ExitCode)
+
+	// Templates processing run stuck
+	app.setRunStarted(time.Now().Add(-time.Hour))
+	code, _ = get("/healthz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+}
+
+func TestAppProcessedClearsError(t *testing.T) {
+	f := newTestFixture(t, fake.NewSimpleClientset(), false)
+	defer f.Close()
+
+	tmpl := f.newTemplate("test", &TemplateDescriptor{})
+	app := f.newApp(tmpl)
+
+	app.processed(tmpl, time.Now(), false, newTemplateError(tmpl, ErrorClassRender, errors.New("failed")))
+	ts := app.status().Templates[0]
+	require.Equal(t, "can't render template 0.tmpl: failed", ts.LastError)
+	require.NotNil(t, ts.LastErrorTime)
+	require.False(t, ts.Rendered)
+
+	app.processed(tmpl, time.Now(), true, nil)
+	ts = app.status().Templates[0]
+	require.Empty(t, ts.LastError)
+	require.Nil(t, ts.LastErrorTime)
+	require.True(t, ts.Rendered)
+}
@@ -0,0 +1,192 @@
+// Copyright © 2015 Victor Antonovich <victor@antonovich.me>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Template source schemes, local file is used if none
+const (
+	SourceSchemeConfigMap = "configmap"
+	SourceSchemeHTTP      = "http"
+	SourceSchemeHTTPS     = "https"
+)
+
+// Timeout of template source HTTP requests
+const SourceHTTPTimeout = 30 * time.Second
+
+// Template data source
+type templateSource interface {
+	fmt.Stringer
+	// Read template data, returns false if data not changed since last read
+	Read() ([]byte, bool, error)
+	// Check template data can be affected by given Kubernetes objects changes
+	affectedBy(changes []objectChange) bool
+	// Check template data should be polled for changes
+	polled() bool
+}
+
+// Create template source for given path: local file, 'configmap://namespace/name/key' or HTTP(S) URL
+func newTemplateSource(dm *DependencyManager, path string) (templateSource, error) {
+	switch {
+	case strings.HasPrefix(path, SourceSchemeConfigMap+"://"):
+		_, namespace, name, key, err := parseObjectKeyURL(path, SourceSchemeConfigMap)
+		if err != nil {
+			return nil, err
+		}
+		return &configMapSource{dm: dm, namespace: namespace, name: name, key: key}, nil
+	case strings.HasPrefix(path, SourceSchemeHTTP+"://"), strings.HasPrefix(path, SourceSchemeHTTPS+"://"):
+		return &httpSource{url: path, client: &http.Client{Timeout: SourceHTTPTimeout}}, nil
+	default:
+		return &fileSource{path: path}, nil
+	}
+}
+
+// Local file template source, read once (reloaded with app on SIGHUP)
+type fileSource struct {
+	path string
+	read bool
+}
+
+func (s *fileSource) String() string {
+	return s.path
+}
+
+func (s *fileSource) Read() ([]byte, bool, error) {
+	if s.read {
+		return nil, false, nil
+	}
+	data, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return nil, false, err
+	}
+	s.read = true
+	return data, true, nil
+}
+
+func (s *fileSource) affectedBy(_ []objectChange) bool {
+	return false
+}
+
+func (s *fileSource) polled() bool {
+	return false
+}
+
+// ConfigMap key template source, watched using Kubernetes objects informers
+type configMapSource struct {
+	dm        *DependencyManager
+	namespace string
+	name      string
+	key       string
+	// Last read data
+	data []byte
+	read bool
+}
+
+func (s *configMapSource) String() string {
+	return fmt.Sprintf("%s://%s/%s/%s", SourceSchemeConfigMap, s.namespace, s.name, s.key)
+}
+
+func (s *configMapSource) Read() ([]byte, bool, error) {
+	cm, err := s.dm.client.ConfigMap(s.namespace, s.name)
+	if apierrors.IsNotFound(err) {
+		return nil, false, fmt.Errorf("configmap %s/%s not found", s.namespace, s.name)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	data, found := cm.BinaryData[s.key]
+	if v, ok := cm.Data[s.key]; ok {
+		data, found = []byte(v), true
+	}
+	if !found {
+		return nil, false, fmt.Errorf("key %q not found in configmap %s/%s", s.key, s.namespace, s.name)
+	}
+	changed := !s.read || !bytes.Equal(data, s.data)
+	s.data, s.read = data, true
+	return data, changed, nil
+}
+
+func (s *configMapSource) affectedBy(changes []objectChange) bool {
+	key := dependencyKey{resource: "configmaps", namespace: s.namespace, name: s.name}
+	for _, change := range changes {
+		if key.affectedBy(change) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *configMapSource) polled() bool {
+	return false
+}
+
+// HTTP(S) URL template source, polled using conditional requests
+type httpSource struct {
+	url    string
+	client *http.Client
+	// Last read data and its entity tag
+	data []byte
+	etag string
+	read bool
+}
+
+func (s *httpSource) String() string {
+	return s.url
+}
+
+func (s *httpSource) Read() ([]byte, bool, error) {
+	req, err := http.NewRequest(http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	if s.read && s.etag != "" {
+		req.Header.Set("If-None-Match", s.etag)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer CloseQuietly(resp.Body)
+	if resp.StatusCode == http.StatusNotModified && s.read {
+		return nil, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("can't get %s: unexpected response status: %s", s.url, resp.Status)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	changed := !s.read || !bytes.Equal(data, s.data)
+	s.data, s.etag, s.read = data, resp.Header.Get("ETag"), true
+	return data, changed, nil
+}
+
+func (s *httpSource) affectedBy(_ []objectChange) bool {
+	return false
+}
+
+func (s *httpSource) polled() bool {
+	return true
+}
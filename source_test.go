@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/kubernetes/pkg/controller/testutil"
+)
+
+func TestNewTemplateSource(t *testing.T) {
+	s, err := newTemplateSource(nil, "templates/pods.tmpl")
+	require.NoError(t, err)
+	require.Equal(t, &fileSource{path: "templates/pods.tmpl"}, s)
+
+	s, err = newTemplateSource(nil, "configmap://ns1/templates/pods.tmpl")
+	require.NoError(t, err)
+	require.Equal(t, &configMapSource{namespace: "ns1", name: "templates", key: "pods.tmpl"}, s)
+	require.Equal(t, "configmap://ns1/templates/pods.tmpl", s.String())
+
+	s, err = newTemplateSource(nil, "https://example.com:8443/pods.tmpl")
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com:8443/pods.tmpl", s.String())
+	require.True(t, s.polled())
+
+	_, err = newTemplateSource(nil, "configmap://ns1/templates")
+	require.Error(t, err)
+}
+
+func TestHTTPSource(t *testing.T) {
+	var lock sync.Mutex
+	data, etag, notModified := "pods: {{len (pods)}}\n", `"v1"`, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		fmt.Fprint(w, data)
+	}))
+	defer server.Close()
+
+	f := newTestFixture(t, fake.NewSimpleClientset(testutil.NewPod("pod1", "host1")), false)
+	defer f.Close()
+
+	tmpl := f.newTemplate("", &TemplateDescriptor{Path: server.URL + "/pods.tmpl", Output: "pods.txt"})
+	require.Equal(t, "pods.tmpl", tmpl.name)
+	output := tmpl.desc.Output
+
+	// Not modified source is not reloaded
+	reloaded, err := tmpl.reload()
+	require.NoError(t, err)
+	require.False(t, reloaded)
+	require.Equal(t, 1, notModified)
+
+	app := f.newApp(tmpl)
+	app.sourcePollPeriod = 10 * time.Millisecond
+	go app.Start()
+	defer func() {
+		app.Stop()
+		<-app.doneCh
+	}()
+
+	outputEquals := func(expected string) func() bool {
+		return func() bool {
+			actual, err := ioutil.ReadFile(output)
+			return err == nil && string(actual) == expected
+		}
+	}
+	require.Eventually(t, outputEquals("pods: 1\n"), 5*time.Second, 10*time.Millisecond)
+
+	// Changed source is reloaded and rendered
+	lock.Lock()
+	data, etag = "pod: {{range pods}}{{.Name}}{{end}}\n", `"v2"`
+	lock.Unlock()
+	require.Eventually(t, outputEquals("pod: pod1\n"), 5*time.Second, 10*time.Millisecond)
+}
+
+func TestConfigMapSource(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "templates", Namespace: DefaultNamespace},
+		Data:       map[string]string{"pods.tmpl": "pods: {{len (pods)}}\n"},
+	}
+	fakeClient := fake.NewSimpleClientset(testutil.NewPod("pod1", "host1"), cm)
+
+	f := newTestFixture(t, fakeClient, true)
+	defer f.Close()
+
+	tmpl := f.newTemplate("", &TemplateDescriptor{Path: "configmap://default/templates/pods.tmpl", Output: "pods.txt"})
+	output := tmpl.desc.Output
+
+	app := f.newApp(tmpl)
+	go app.Start()
+	defer func() {
+		app.Stop()
+		<-app.doneCh
+	}()
+
+	outputEquals := func(expected string) func() bool {
+		return func() bool {
+			actual, err := ioutil.ReadFile(output)
+			return err == nil && string(actual) == expected
+		}
+	}
+	require.Eventually(t, outputEquals("pods: 1\n"), 5*time.Second, 10*time.Millisecond)
+
+	// Changed ConfigMap is reloaded and rendered without app reloading
+	cm = cm.DeepCopy()
+	cm.Data["pods.tmpl"] = "pod: {{range pods}}{{.Name}}{{end}}\n"
+	_, err := fakeClient.CoreV1().ConfigMaps(DefaultNamespace).Update(context.TODO(), cm, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, outputEquals("pod: pod1\n"), 5*time.Second, 10*time.Millisecond)
+
+	// Missing key is an error
+	_, err = newTemplate(&Config{LeftDelimiter: "{{", RightDelimiter: "}}"}, f.dm,
+		&TemplateDescriptor{Path: "configmap://default/templates/missing.tmpl", Output: output})
+	require.Error(t, err)
+}
@@ -0,0 +1,314 @@
+// Copyright © 2015 Victor Antonovich <victor@antonovich.me>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagExecReloadSignal = "exec-reload-signal"
+	FlagExecKillTimeout  = "exec-kill-timeout"
+)
+
+// Exit code if child process couldn't be started
+const ExitCodeExecFailed = 127
+
+func newExecCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exec -- command [args...]",
+		Short: "Run and supervise child process",
+		Long: `Starts child process after all templates are rendered, forwards signals to it and reloads
+(or restarts) it when template outputs are updated. Exits with child process exit code.`,
+		Args: cobra.MinimumNArgs(1),
+		Run:  runExecCmd,
+	}
+	f := cmd.Flags()
+	f.String(FlagExecReloadSignal, "SIGHUP", `signal to send to child process when template outputs are updated
+		(empty to restart child process instead)`)
+	f.Duration(FlagExecKillTimeout, 30*time.Second, `time to wait for child process to exit after termination
+		signal before killing it`)
+	return cmd
+}
+
+func runExecCmd(cmd *cobra.Command, args []string) {
+	var reloadSignal os.Signal
+	if s, _ := cmd.Flags().GetString(FlagExecReloadSignal); s != "" {
+		sig, err := ParseSignal(s)
+		if err != nil {
+			glog.Fatalf("invalid reload signal: %v", err)
+		}
+		reloadSignal = sig
+	}
+	killTimeout, _ := cmd.Flags().GetDuration(FlagExecKillTimeout)
+
+	config, err := getConfig(cmd)
+	if err != nil {
+		glog.Fatalf("config error: %v, exiting...", err)
+	}
+	if config.RunOnce || config.DryRun {
+		glog.Fatalf("exec mode can't be used with --%s or --%s", FlagRunOnce, FlagDryRun)
+	}
+
+	app, err := newApp(config)
+	if err != nil {
+		glog.Fatalf("config couldn't be used: %v", err)
+	}
+
+	server, err := startServer(cmd)
+	if err != nil {
+		glog.Fatalf("can't start HTTP server: %v", err)
+	}
+	server.setApp(app)
+
+	sv := newSupervisor(args, reloadSignal, killTimeout)
+	app.onRun = sv.onRun
+
+	// Start templates processing
+	go app.Start()
+
+	// Listen for signals
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, append([]os.Signal{
+		syscall.SIGHUP,
+		syscall.SIGINT,
+		syscall.SIGTERM,
+		syscall.SIGQUIT,
+	}, forwardedSignals...)...)
+
+	// Event loop
+	var exitCode int
+EventLoop:
+	for {
+		select {
+		case sig := <-signalCh:
+			switch sig {
+			case syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT:
+				glog.V(2).Infof("received %v signal...", sig)
+				// Stop templates processing and child process, then exit
+				app.Stop()
+				<-app.doneCh
+				exitCode = sv.stop(sig)
+				break EventLoop
+			case syscall.SIGHUP:
+				glog.V(2).Infof("received %v signal, reloading config", sig)
+				app = reloadApp(cmd, app, sv.onRun)
+				server.setApp(app)
+			default:
+				sv.signal(sig)
+			}
+		case exitCode = <-sv.exitCh:
+			// Child process exited, stop templates processing and exit
+			app.Stop()
+			<-app.doneCh
+			break EventLoop
+		}
+	}
+
+	glog.V(1).Infof("exiting with code %d", exitCode)
+	flushLogs()
+	os.Exit(exitCode)
+}
+
+// Child process supervisor
+type Supervisor struct {
+	sync.Mutex
+
+	// Child process command line
+	args []string
+	// Signal to send to child process on templates outputs update, nil to restart it
+	reloadSignal os.Signal
+	// Time to wait for child process to exit after termination signal
+	killTimeout time.Duration
+
+	// Running child process, if any
+	child *childProcess
+	// Exit codes of child processes exited by themselves
+	exitCh chan int
+	// Child process exited or stopped, so it shouldn't be started anymore
+	done bool
+}
+
+// Child process started by supervisor
+type childProcess struct {
+	cmd *exec.Cmd
+	// Closed when process exited
+	doneCh chan struct{}
+	// Process exit code
+	exitCode int
+}
+
+func newSupervisor(args []string, reloadSignal os.Signal, killTimeout time.Duration) *Supervisor {
+	return &Supervisor{
+		args:         args,
+		reloadSignal: reloadSignal,
+		killTimeout:  killTimeout,
+		exitCh:       make(chan int, 1),
+	}
+}
+
+// Templates processing run hook: starts child process when all templates are rendered,
+// reloads it if template outputs are updated
+func (sv *Supervisor) onRun(app *App, updated []*Template) {
+	sv.Lock()
+	defer sv.Unlock()
+
+	if sv.done {
+		return
+	}
+
+	if sv.child == nil {
+		if !app.allRendered() {
+			glog.V(2).Infoln("not all templates are rendered yet, child process not started")
+			return
+		}
+		sv.start()
+		return
+	}
+
+	if len(updated) == 0 {
+		return
+	}
+
+	if sv.reloadSignal != nil {
+		glog.V(2).Infof("sending %v signal to child process", sv.reloadSignal)
+		if err := sv.child.cmd.Process.Signal(sv.reloadSignal); err != nil {
+			glog.Errorf("can't send %v signal to child process: %v", sv.reloadSignal, err)
+		}
+		return
+	}
+
+	glog.V(2).Infoln("restarting child process")
+	child := sv.child
+	sv.child = nil
+	child.stop(syscall.SIGTERM, sv.killTimeout)
+	sv.start()
+}
+
+// Start child process, should be called with lock held
+func (sv *Supervisor) start() {
+	cmd := exec.Command(sv.args[0], sv.args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		glog.Errorf("can't start child process: %v", err)
+		sv.exited(ExitCodeExecFailed)
+		return
+	}
+	glog.V(1).Infof("started child process: %v (pid %d)", sv.args, cmd.Process.Pid)
+
+	child := &childProcess{
+		cmd:    cmd,
+		doneCh: make(chan struct{}),
+	}
+	sv.child = child
+
+	go func() {
+		child.exitCode = exitCode(cmd.Wait())
+		close(child.doneCh)
+		glog.V(1).Infof("child process exited with code %d", child.exitCode)
+		sv.Lock()
+		defer sv.Unlock()
+		// Report exit of current child process only, not restarted one
+		if sv.child == child {
+			sv.child = nil
+			sv.exited(child.exitCode)
+		}
+	}()
+}
+
+// Report child process exit with given code, if not reported yet. Should be called with lock held.
+func (sv *Supervisor) exited(code int) {
+	sv.done = true
+	select {
+	case sv.exitCh <- code:
+	default:
+	}
+}
+
+// Forward given signal to child process, if running
+func (sv *Supervisor) signal(sig os.Signal) {
+	sv.Lock()
+	defer sv.Unlock()
+
+	if sv.child == nil {
+		glog.V(2).Infof("no child process to forward %v signal to", sig)
+		return
+	}
+	glog.V(2).Infof("forwarding %v signal to child process", sig)
+	if err := sv.child.cmd.Process.Signal(sig); err != nil {
+		glog.Errorf("can't forward %v signal to child process: %v", sig, err)
+	}
+}
+
+// Stop child process, if running, with given signal. Returns child process exit code.
+func (sv *Supervisor) stop(sig os.Signal) int {
+	sv.Lock()
+	child := sv.child
+	sv.child, sv.done = nil, true
+	sv.Unlock()
+
+	if child == nil {
+		// Child process exited by itself meanwhile
+		select {
+		case code := <-sv.exitCh:
+			return code
+		default:
+			return 0
+		}
+	}
+	return child.stop(sig, sv.killTimeout)
+}
+
+// Stop child process with given signal, killing it if not exited in time. Returns exit code.
+func (p *childProcess) stop(sig os.Signal, killTimeout time.Duration) int {
+	if err := p.cmd.Process.Signal(sig); err != nil {
+		glog.Errorf("can't send %v signal to child process: %v", sig, err)
+	}
+	select {
+	case <-p.doneCh:
+	case <-time.After(killTimeout):
+		glog.Warningf("child process not exited in %v, killing", killTimeout)
+		if err := killProcessGroup(p.cmd); err != nil {
+			glog.Errorf("can't kill child process: %v", err)
+		}
+		<-p.doneCh
+	}
+	return p.exitCode
+}
+
+// Returns process exit code from given wait error, using 128+n for processes killed by signal n
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return ExitCodeExecFailed
+	}
+	if sig, ok := exitSignal(exitErr.ProcessState).(syscall.Signal); ok {
+		return 128 + int(sig)
+	}
+	return exitErr.ExitCode()
+}
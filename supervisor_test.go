@@ -0,0 +1,111 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readTestFile(path string) string {
+	data, _ := ioutil.ReadFile(path)
+	return string(data)
+}
+
+func TestSupervisorReloadSignal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kube-template")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	out := filepath.Join(dir, "out")
+	sv := newSupervisor([]string{"sh", "-c",
+		"trap 'echo reloaded >> " + out + "' HUP; echo started >> " + out + "; while true; do sleep 0.05; done"},
+		syscall.SIGHUP, 5*time.Second)
+
+	tmpl := &Template{}
+	app := &App{templates: []*Template{tmpl}}
+
+	// Child process is not started until all templates are rendered
+	sv.onRun(app, nil)
+	require.Nil(t, sv.child)
+
+	tmpl.rendered = true
+	sv.onRun(app, nil)
+	defer sv.stop(syscall.SIGKILL)
+	require.NotNil(t, sv.child)
+	require.Eventually(t, func() bool {
+		return readTestFile(out) == "started\n"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Not updated templates don't trigger child process reload
+	sv.onRun(app, nil)
+	sv.onRun(app, []*Template{tmpl})
+	require.Eventually(t, func() bool {
+		return readTestFile(out) == "started\nreloaded\n"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Equal(t, 128+int(syscall.SIGTERM), sv.stop(syscall.SIGTERM))
+}
+
+func TestSupervisorRestart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kube-template")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	out := filepath.Join(dir, "out")
+	sv := newSupervisor([]string{"sh", "-c", "echo started >> " + out + "; exec sleep 30"}, nil, 5*time.Second)
+
+	tmpl := &Template{rendered: true}
+	app := &App{templates: []*Template{tmpl}}
+
+	sv.onRun(app, nil)
+	defer sv.stop(syscall.SIGKILL)
+	child := sv.child
+	require.NotNil(t, child)
+	require.Eventually(t, func() bool {
+		return readTestFile(out) == "started\n"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	sv.onRun(app, []*Template{tmpl})
+	require.NotNil(t, sv.child)
+	require.NotEqual(t, child, sv.child)
+	<-child.doneCh
+	require.Eventually(t, func() bool {
+		return readTestFile(out) == "started\nstarted\n"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Exit of restarted child process is not reported
+	select {
+	case <-sv.exitCh:
+		t.Fatal("restarted child process exit reported")
+	default:
+	}
+
+	require.Equal(t, 128+int(syscall.SIGTERM), sv.stop(syscall.SIGTERM))
+}
+
+func TestSupervisorChildExit(t *testing.T) {
+	sv := newSupervisor([]string{"sh", "-c", "exit 3"}, syscall.SIGHUP, time.Second)
+
+	app := &App{templates: []*Template{{rendered: true}}}
+	sv.onRun(app, nil)
+
+	select {
+	case code := <-sv.exitCh:
+		require.Equal(t, 3, code)
+	case <-time.After(5 * time.Second):
+		t.Fatal("child process exit not reported")
+	}
+
+	// Exited child process is not started again
+	sv.onRun(app, nil)
+	require.Nil(t, sv.child)
+
+	sv = newSupervisor([]string{filepath.Join(os.TempDir(), "no-such-command-kube-template")}, nil, time.Second)
+	sv.onRun(app, nil)
+	require.Equal(t, ExitCodeExecFailed, <-sv.exitCh)
+}
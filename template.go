@@ -16,23 +16,40 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 
 	gotemplate "text/template"
 
 	"strings"
 
 	"github.com/Masterminds/sprig/v3"
+	"github.com/golang/glog"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 const (
-	DefaultNamespace = metav1.NamespaceDefault
-	DefaultSelector  = ""
+	DefaultNamespace     = metav1.NamespaceDefault
+	DefaultSelector      = ""
+	DefaultFieldSelector = ""
+	// Namespace argument to query all namespaces
+	AllNamespaces = "*"
+	// Template output file permissions if not configured
+	DefaultOutputPerms = 0644
+)
+
+// Template tag options map keys
+const (
+	OptionSelector  = "selector"
+	OptionFields    = "fields"
+	OptionNamespace = "namespace"
 )
 
 type Template struct {
@@ -42,39 +59,112 @@ type Template struct {
 	// Template name (base file name)
 	name string
 
+	// Template data source
+	source templateSource
+
+	// Template delimiters
+	leftDelim, rightDelim string
+
 	// Go template to render
 	template *gotemplate.Template
 
+	// Dependency manager
+	dm *DependencyManager
+
+	// Dependencies used during last rendering (nil if unknown)
+	deps map[dependencyKey]bool
+
 	// Template last output (in case of successfully rendered template)
 	lastOutput string
+
+	// Template output ConfigMap or Secret key (nil if output is a file)
+	kubeOutput *kubeOutput
+
+	// Template output file owner user and group ids (-1 if not changed)
+	uid, gid int
+
+	// Output check command template (nil if not set)
+	check *gotemplate.Template
+
+	// Output file contents before last write, to roll back to
+	backup        []byte
+	backupPresent bool
+
+	// Template rendering quiescence timer
+	quiescence quiescence
+
+	// Template was successfully processed at least once
+	rendered bool
+
+	// Template processing status, protected by app status lock
+	status TemplateStatus
 }
 
 func newTemplate(cfg *Config, dm *DependencyManager, d *TemplateDescriptor) (*Template, error) {
 	// Template name
 	name := filepath.Base(d.Path)
 	// Get last template output, if present
-	o, err := ioutil.ReadFile(d.Output)
-	if err != nil {
-		o = nil
+	var o []byte
+	var output *kubeOutput
+	if isKubeOutput(d.Output) {
+		if d.Perms != 0 || d.User != "" || d.Group != "" {
+			return nil, fmt.Errorf("template %s: output file options can't be used with output %s", name, d.Output)
+		}
+		var err error
+		if output, err = newKubeOutput(dm.client.kubeClient, d.Output); err != nil {
+			return nil, err
+		}
+		if o, _, err = output.Read(); err != nil {
+			glog.Warningf("template %s: can't get last output from %s: %v", name, output, err)
+		}
+	} else if data, err := ioutil.ReadFile(d.Output); err == nil {
+		o = data
 	}
 	// Read template data
-	data, err := ioutil.ReadFile(d.Path)
+	source, err := newTemplateSource(dm, d.Path)
 	if err != nil {
 		return nil, err
 	}
-	s := string(data)
-	// Create Go template from read data
-	template, err := gotemplate.New(name).Delims(cfg.LeftDelimiter, cfg.RightDelimiter).Funcs(funcMap(dm)).Parse(s)
+	data, _, err := source.Read()
+	if err != nil {
+		return nil, err
+	}
+	// Resolve output file owner
+	uid, err := LookupUserId(d.User)
+	if err != nil {
+		return nil, err
+	}
+	gid, err := LookupGroupId(d.Group)
 	if err != nil {
 		return nil, err
 	}
+	// Create output check command template, if set
+	var check *gotemplate.Template
+	if d.Check != "" {
+		if check, err = gotemplate.New(name + " check").Parse(d.Check); err != nil {
+			return nil, err
+		}
+	}
 	// Create template
-	return &Template{
+	t := &Template{
 		desc:       d,
 		name:       name,
-		template:   template,
+		source:     source,
+		leftDelim:  cfg.LeftDelimiter,
+		rightDelim: cfg.RightDelimiter,
+		dm:         dm,
 		lastOutput: string(o),
-	}, nil
+		kubeOutput: output,
+		uid:        uid,
+		gid:        gid,
+		check:      check,
+		quiescence: quiescence{wait: d.Wait},
+	}
+	// Create Go template from read data
+	if t.template, err = t.parse(data); err != nil {
+		return nil, err
+	}
+	return t, nil
 }
 
 func newTemplatesFromConfig(cfg *Config, dm *DependencyManager) ([]*Template, error) {
@@ -89,6 +179,27 @@ func newTemplatesFromConfig(cfg *Config, dm *DependencyManager) ([]*Template, er
 	return templates, nil
 }
 
+// Create Go template from given data
+func (t *Template) parse(data []byte) (*gotemplate.Template, error) {
+	return gotemplate.New(t.name).Delims(t.leftDelim, t.rightDelim).Funcs(funcMap(t.dm)).Parse(string(data))
+}
+
+// Reload template from its source, if changed. Returns true if template was reloaded.
+func (t *Template) reload() (bool, error) {
+	data, changed, err := t.source.Read()
+	if err != nil || !changed {
+		return false, err
+	}
+	template, err := t.parse(data)
+	if err != nil {
+		return false, err
+	}
+	t.template = template
+	// Dependencies of reloaded template are unknown until rendered
+	t.deps = nil
+	return true, nil
+}
+
 func (t *Template) Process(dryRun bool) (bool, error) {
 	if r, err := t.Render(); err == nil {
 		if changed := !(r == t.lastOutput); changed {
@@ -96,70 +207,193 @@ func (t *Template) Process(dryRun bool) (bool, error) {
 			if !dryRun {
 				if err := t.Write([]byte(r)); err != nil {
 					// Can't write template output
-					return false, err
+					return false, newTemplateError(t, ErrorClassWrite, err)
 				}
 			}
 			t.lastOutput = r
+			t.rendered = true
 			return true, nil
 		}
 		// Template output not changed
+		t.rendered = true
 		return false, nil
 	} else {
 		// Can't render template
-		return false, err
+		return false, newTemplateError(t, ErrorClassRender, err)
 	}
 }
 
+// Output check command template data, file names are shell-quoted
+type checkData struct {
+	// Temp file with new template output
+	TempFile string
+	// Template output file
+	Output string
+}
+
+// Write given content to template output, saving its current contents for rollback
 func (t *Template) Write(content []byte) error {
-	dir := filepath.Dir(t.desc.Output)
-	if _, err := os.Stat(t.desc.Output); os.IsNotExist(err) {
-		// Output file does not exist, create intermediate dirs and write directly
-		if err := os.MkdirAll(dir, 0755); err != nil {
+	if t.kubeOutput != nil {
+		backup, present, err := t.kubeOutput.Read()
+		if err != nil {
 			return err
 		}
-		// TODO file mode from config
-		if err := ioutil.WriteFile(t.desc.Output, content, 0644); err != nil {
+		if err := t.writeKube(content, true); err != nil {
 			return err
 		}
-	} else {
-		// Output file exist, update atomically using temp file
-		var f *os.File
-		if f, err = ioutil.TempFile(dir, t.name); err != nil {
+		t.backup, t.backupPresent = backup, present
+		return nil
+	}
+	backup, readErr := ioutil.ReadFile(t.desc.Output)
+	if readErr != nil && !os.IsNotExist(readErr) {
+		return readErr
+	}
+	if err := t.write(content, true); err != nil {
+		return err
+	}
+	t.backup, t.backupPresent = backup, readErr == nil
+	return nil
+}
+
+// Restore template output contents saved before last write. Last output is kept as rendered,
+// so the rolled back output is not written again until the rendered template changes.
+func (t *Template) Rollback() error {
+	if t.kubeOutput != nil {
+		if t.backupPresent {
+			if err := t.writeKube(t.backup, false); err != nil {
+				return err
+			}
+		} else if err := t.kubeOutput.Remove(); err != nil {
 			return err
 		}
-		defer UnlinkQuietly(f.Name())
-		// Write template output to temp file
-		if _, err := f.Write(content); err != nil {
+	} else if t.backupPresent {
+		if err := t.write(t.backup, false); err != nil {
 			return err
 		}
-		if err := f.Sync(); err != nil {
+	} else if err := os.Remove(t.desc.Output); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	t.backup, t.backupPresent = nil, false
+	return nil
+}
+
+func (t *Template) write(content []byte, check bool) error {
+	dir := filepath.Dir(t.desc.Output)
+	// Create intermediate dirs, if needed
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	// Update output file atomically using temp file
+	f, err := ioutil.TempFile(dir, t.name)
+	if err != nil {
+		return err
+	}
+	defer UnlinkQuietly(f.Name())
+	defer CloseQuietly(f)
+	// Write template output to temp file
+	if _, err := f.Write(content); err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	// Set output file mode and owner
+	perms := t.desc.Perms
+	if perms == 0 {
+		perms = DefaultOutputPerms
+	}
+	if err := os.Chmod(f.Name(), perms); err != nil {
+		return err
+	}
+	if t.uid != -1 || t.gid != -1 {
+		if err := os.Chown(f.Name(), t.uid, t.gid); err != nil {
 			return err
 		}
-		if err := f.Close(); err != nil {
+	}
+	// Check new output before replacing output file with it
+	if check {
+		if err := t.checkOutput(f.Name()); err != nil {
+			return err
+		}
+	}
+	// Rename temp file to output file
+	return os.Rename(f.Name(), t.desc.Output)
+}
+
+// Write given content to template output ConfigMap or Secret key
+func (t *Template) writeKube(content []byte, check bool) error {
+	if check && t.check != nil {
+		// Output check command needs new output in temp file
+		f, err := ioutil.TempFile("", t.name)
+		if err != nil {
 			return err
 		}
-		// TODO file mode from config
-		if err := os.Chmod(f.Name(), 0644); err != nil {
+		defer UnlinkQuietly(f.Name())
+		defer CloseQuietly(f)
+		if _, err := f.Write(content); err != nil {
+			return err
+		}
+		if err := f.Close(); err != nil {
 			return err
 		}
-		// Rename temp file to output file
-		if err := os.Rename(f.Name(), t.desc.Output); err != nil {
+		if err := t.checkOutput(f.Name()); err != nil {
 			return err
 		}
 	}
+	return t.kubeOutput.Write(content)
+}
+
+// Run output check command, if set, for new output in given temp file
+func (t *Template) checkOutput(tempFile string) error {
+	if t.check == nil {
+		return nil
+	}
+	buf := new(bytes.Buffer)
+	if err := t.check.Execute(buf, checkData{TempFile: ShellQuote(tempFile), Output: ShellQuote(t.desc.Output)}); err != nil {
+		return err
+	}
+	cmd := buf.String()
+	glog.V(4).Infof("template %s: checking output: %q", t.name, cmd)
+	if err := Execute(Command{Line: cmd, Env: commandEnv([]*Template{t}, false)}, t.desc.CommandTimeout, t.desc.CommandKillTimeout); err != nil {
+		return fmt.Errorf("template %s: output check %q failed: %v", t.name, cmd, err)
+	}
 	return nil
 }
 
 func (t *Template) Render() (string, error) {
-	// Render template to buffer
+	// Render template to buffer, recording dependencies used
 	buf := new(bytes.Buffer)
-	if err := t.template.Execute(buf, nil); err != nil {
+	t.dm.startRecording()
+	err := t.template.Execute(buf, nil)
+	deps := t.dm.stopRecording()
+	if err != nil {
+		// Dependencies may be recorded partially, so consider them unknown
+		t.deps = nil
 		return "", err
 	}
+	t.deps = deps
 
 	return buf.String(), nil
 }
 
+// Check template output can be affected by given Kubernetes objects changes
+func (t *Template) affectedBy(changes []objectChange) bool {
+	if t.deps == nil {
+		return true
+	}
+	for key := range t.deps {
+		for _, change := range changes {
+			if key.affectedBy(change) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func funcMap(dm *DependencyManager) gotemplate.FuncMap {
 	f := gotemplate.FuncMap{
 		// Legacy helper functions
@@ -173,6 +407,7 @@ func funcMap(dm *DependencyManager) gotemplate.FuncMap {
 	for k, v := range kubeObjectsFuncMap(dm) {
 		f[k] = v
 	}
+	f["resources"] = resources(dm)
 
 	// Sprig helper functions
 	for k, v := range sprig.FuncMap() {
@@ -182,33 +417,223 @@ func funcMap(dm *DependencyManager) gotemplate.FuncMap {
 	return f
 }
 
-// Parse template tag with max 1 argument - selector
-func parseSelector(s ...string) (string, error) {
-	selector := DefaultSelector
-	switch len(s) {
+// Parse template tag arguments given either as positional ones or as a single
+// options map (e.g. created by 'dict' function) with given allowed keys
+func parseArgs(s []interface{}, keys ...string) ([]interface{}, map[string]interface{}, error) {
+	if len(s) == 1 {
+		if m, ok := s[0].(map[string]interface{}); ok {
+			for k := range m {
+				if !IsPresent(keys, k) {
+					return nil, nil, fmt.Errorf("unknown option %q, expected one of %v", k, keys)
+				}
+			}
+			return nil, m, nil
+		}
+	}
+	return s, nil, nil
+}
+
+// Convert template tag argument to string
+func toString(name string, v interface{}) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("%s: expected string value, got %T", name, v)
+	}
+	return s, nil
+}
+
+// Convert template tag namespace argument, given either as a string with comma-separated
+// namespaces or as a list, to list of namespaces. Empty or '*' namespace means all namespaces.
+func toNamespaces(v interface{}) ([]string, error) {
+	var items []string
+	switch n := v.(type) {
+	case string:
+		items = strings.Split(n, ",")
+	case []string:
+		items = n
+	case []interface{}:
+		for _, i := range n {
+			s, err := toString(OptionNamespace, i)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, s)
+		}
+	default:
+		return nil, fmt.Errorf("%s: expected string or list value, got %T", OptionNamespace, v)
+	}
+	var namespaces []string
+	for _, i := range items {
+		namespace := strings.TrimSpace(i)
+		if namespace == AllNamespaces || namespace == metav1.NamespaceAll {
+			// Querying all namespaces makes other ones redundant
+			return []string{metav1.NamespaceAll}, nil
+		}
+		if !IsPresent(namespaces, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	if len(namespaces) == 0 {
+		return []string{metav1.NamespaceAll}, nil
+	}
+	return namespaces, nil
+}
+
+// Parse template tag with max 1 argument - selector, or with options map
+// with 'selector' and 'fields' (field selector) keys
+func parseSelector(s ...interface{}) (string, string, error) {
+	selector, fieldSelector := DefaultSelector, DefaultFieldSelector
+	args, options, err := parseArgs(s, OptionSelector, OptionFields)
+	if err != nil {
+		return "", "", err
+	}
+	if options != nil {
+		if o, ok := options[OptionSelector]; ok {
+			if selector, err = toString(OptionSelector, o); err != nil {
+				return "", "", err
+			}
+		}
+		if o, ok := options[OptionFields]; ok {
+			if fieldSelector, err = toString(OptionFields, o); err != nil {
+				return "", "", err
+			}
+		}
+		return selector, fieldSelector, nil
+	}
+	switch len(args) {
 	case 0:
 		break
 	case 1:
-		selector = s[0]
+		if selector, err = toString(OptionSelector, args[0]); err != nil {
+			return "", "", err
+		}
 	default:
-		return "", fmt.Errorf("expected max 1 argument, got %d", len(s))
+		return "", "", fmt.Errorf("expected max 1 argument, got %d", len(args))
 	}
-	return selector, nil
+	return selector, fieldSelector, nil
 }
 
-// Parse template tag with max 2 arguments - selector and namespace (in given order)
-func parseNamespaceSelector(s ...string) (string, string, error) {
-	namespace, selector := DefaultNamespace, DefaultSelector
+// Parse template tag with max 2 arguments - selector and namespaces (in given order),
+// or with options map with 'selector', 'fields' (field selector) and 'namespace' keys.
+// Given default namespace is used if namespaces are not specified.
+func parseNamespaceSelector(defaultNamespace string, s ...interface{}) ([]string, string, string, error) {
+	namespaces, selector, fieldSelector := []string{defaultNamespace}, DefaultSelector, DefaultFieldSelector
+	args, options, err := parseArgs(s, OptionSelector, OptionFields, OptionNamespace)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if options != nil {
+		if o, ok := options[OptionSelector]; ok {
+			if selector, err = toString(OptionSelector, o); err != nil {
+				return nil, "", "", err
+			}
+		}
+		if o, ok := options[OptionFields]; ok {
+			if fieldSelector, err = toString(OptionFields, o); err != nil {
+				return nil, "", "", err
+			}
+		}
+		if o, ok := options[OptionNamespace]; ok {
+			if namespaces, err = toNamespaces(o); err != nil {
+				return nil, "", "", err
+			}
+		}
+		return namespaces, selector, fieldSelector, nil
+	}
+	switch len(args) {
+	case 0:
+		break
+	case 2:
+		if namespaces, err = toNamespaces(args[1]); err != nil {
+			return nil, "", "", err
+		}
+		fallthrough
+	case 1:
+		if selector, err = toString(OptionSelector, args[0]); err != nil {
+			return nil, "", "", err
+		}
+	default:
+		return nil, "", "", fmt.Errorf("expected max 2 arguments, got %d", len(args))
+	}
+	return namespaces, selector, fieldSelector, nil
+}
+
+// Parse template tag with max 1 argument - single namespace.
+// Given default namespace is used if namespace is not specified.
+func parseNamespace(defaultNamespace string, s ...string) (string, error) {
+	namespace := defaultNamespace
 	switch len(s) {
 	case 0:
 		break
 	case 1:
-		selector = s[0]
+		namespace = s[0]
+	default:
+		return "", fmt.Errorf("expected max 1 namespace argument, got %d", len(s))
+	}
+	// Empty namespace means all namespaces for list functions, so it can't be used to get single object
+	if namespace == "" || namespace == AllNamespaces || strings.Contains(namespace, ",") {
+		return "", fmt.Errorf("expected single namespace, got %q", namespace)
+	}
+	return namespace, nil
+}
+
+// Check object name given to template tag is not empty
+func checkName(name string) error {
+	if len(strings.TrimSpace(name)) == 0 {
+		return errors.New("object name can't be empty")
+	}
+	return nil
+}
+
+// Parse resource in format 'group/version/resource' ('version/resource' for core group)
+func parseGroupVersionResource(s string) (schema.GroupVersionResource, error) {
+	var gvr schema.GroupVersionResource
+	parts := strings.Split(s, "/")
+	switch len(parts) {
 	case 2:
-		selector = s[0]
-		namespace = s[1]
+		gvr.Version, gvr.Resource = parts[0], parts[1]
+	case 3:
+		gvr.Group, gvr.Version, gvr.Resource = parts[0], parts[1], parts[2]
 	default:
-		return "", "", fmt.Errorf("expected max 2 arguments, got %d", len(s))
+		return gvr, fmt.Errorf("invalid resource %q, should be 'group/version/resource'", s)
+	}
+	if gvr.Version == "" || gvr.Resource == "" {
+		return gvr, fmt.Errorf("invalid resource %q, version and resource can't be empty", s)
+	}
+	gvr.Resource = strings.ToLower(gvr.Resource)
+	return gvr, nil
+}
+
+// {{resources "group/version/resource" "selector" "namespace"}}
+// {{resources "group/version/resource" (dict "selector" "selector" "fields" "fields" "namespace" "namespace")}}
+func resources(dm *DependencyManager) func(string, ...interface{}) ([]unstructured.Unstructured, error) {
+	return func(resource string, s ...interface{}) ([]unstructured.Unstructured, error) {
+		gvr, err := parseGroupVersionResource(resource)
+		if err != nil {
+			return nil, err
+		}
+		namespaces, selector, fieldSelector, err := parseNamespaceSelector(dm.namespace, s...)
+		if err != nil {
+			return nil, err
+		}
+		if len(namespaces) == 1 {
+			return dm.Resources(gvr, namespaces[0], selector, fieldSelector)
+		}
+		var resources []unstructured.Unstructured
+		for _, namespace := range namespaces {
+			r, err := dm.Resources(gvr, namespace, selector, fieldSelector)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, r...)
+		}
+		// Make list order stable
+		sort.Slice(resources, func(i, j int) bool {
+			if resources[i].GetNamespace() != resources[j].GetNamespace() {
+				return resources[i].GetNamespace() < resources[j].GetNamespace()
+			}
+			return resources[i].GetName() < resources[j].GetName()
+		})
+		return resources, nil
 	}
-	return namespace, selector, nil
 }
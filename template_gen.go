@@ -17,35 +17,95 @@
 package main
 
 import (
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 )
 
 func kubeObjectsFuncMap(dm *DependencyManager) map[string]interface{} {
 	return map[string]interface{}{
 		"pods":                   pods(dm),
+		"pod":                    pod(dm),
 		"services":               services(dm),
+		"service":                service(dm),
 		"replicationcontrollers": replicationcontrollers(dm),
+		"replicationcontroller":  replicationcontroller(dm),
 		"events":                 events(dm),
+		"event":                  event(dm),
 		"endpoints":              endpoints(dm),
+		"endpoint":               endpoint(dm),
 		"nodes":                  nodes(dm),
+		"node":                   node(dm),
 		"namespaces":             namespaces(dm),
+		"namespace":              namespace(dm),
 		"componentstatuses":      componentstatuses(dm),
+		"componentstatus":        componentstatus(dm),
 		"configmaps":             configmaps(dm),
+		"configmap":              configmap(dm),
 		"limitranges":            limitranges(dm),
+		"limitrange":             limitrange(dm),
 		"persistentvolumes":      persistentvolumes(dm),
+		"persistentvolume":       persistentvolume(dm),
 		"persistentvolumeclaims": persistentvolumeclaims(dm),
+		"persistentvolumeclaim":  persistentvolumeclaim(dm),
 		"podtemplates":           podtemplates(dm),
+		"podtemplate":            podtemplate(dm),
 		"resourcequotas":         resourcequotas(dm),
+		"resourcequota":          resourcequota(dm),
 		"secrets":                secrets(dm),
+		"secret":                 secret(dm),
 		"serviceaccounts":        serviceaccounts(dm),
+		"serviceaccount":         serviceaccount(dm),
+		"deployments":            deployments(dm),
+		"deployment":             deployment(dm),
+		"statefulsets":           statefulsets(dm),
+		"statefulset":            statefulset(dm),
+		"daemonsets":             daemonsets(dm),
+		"daemonset":              daemonset(dm),
+		"replicasets":            replicasets(dm),
+		"replicaset":             replicaset(dm),
 	}
 }
 
 // {{pods "selector" "namespace"}}
-func pods(dm *DependencyManager) func(...string) ([]corev1.Pod, error) {
-	return func(s ...string) ([]corev1.Pod, error) {
-		if namespace, selector, err := parseNamespaceSelector(s...); err == nil {
-			return dm.Pods(namespace, selector)
+// {{pods (dict "selector" "selector" "fields" "fields" "namespace" "namespace")}}
+func pods(dm *DependencyManager) func(...interface{}) ([]corev1.Pod, error) {
+	return func(s ...interface{}) ([]corev1.Pod, error) {
+		namespaces, selector, fieldSelector, err := parseNamespaceSelector(dm.namespace, s...)
+		if err != nil {
+			return nil, err
+		}
+		if len(namespaces) == 1 {
+			return dm.Pods(namespaces[0], selector, fieldSelector)
+		}
+		var pods []corev1.Pod
+		for _, namespace := range namespaces {
+			o, err := dm.Pods(namespace, selector, fieldSelector)
+			if err != nil {
+				return nil, err
+			}
+			pods = append(pods, o...)
+		}
+		// Make list order stable
+		sort.Slice(pods, func(i, j int) bool {
+			if pods[i].Namespace != pods[j].Namespace {
+				return pods[i].Namespace < pods[j].Namespace
+			}
+			return pods[i].Name < pods[j].Name
+		})
+		return pods, nil
+	}
+}
+
+// {{pod "name" "namespace"}}
+func pod(dm *DependencyManager) func(string, ...string) (*corev1.Pod, error) {
+	return func(name string, s ...string) (*corev1.Pod, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+		if namespace, err := parseNamespace(dm.namespace, s...); err == nil {
+			return dm.Pod(namespace, name)
 		} else {
 			return nil, err
 		}
@@ -53,10 +113,43 @@ func pods(dm *DependencyManager) func(...string) ([]corev1.Pod, error) {
 }
 
 // {{services "selector" "namespace"}}
-func services(dm *DependencyManager) func(...string) ([]corev1.Service, error) {
-	return func(s ...string) ([]corev1.Service, error) {
-		if namespace, selector, err := parseNamespaceSelector(s...); err == nil {
-			return dm.Services(namespace, selector)
+// {{services (dict "selector" "selector" "fields" "fields" "namespace" "namespace")}}
+func services(dm *DependencyManager) func(...interface{}) ([]corev1.Service, error) {
+	return func(s ...interface{}) ([]corev1.Service, error) {
+		namespaces, selector, fieldSelector, err := parseNamespaceSelector(dm.namespace, s...)
+		if err != nil {
+			return nil, err
+		}
+		if len(namespaces) == 1 {
+			return dm.Services(namespaces[0], selector, fieldSelector)
+		}
+		var services []corev1.Service
+		for _, namespace := range namespaces {
+			o, err := dm.Services(namespace, selector, fieldSelector)
+			if err != nil {
+				return nil, err
+			}
+			services = append(services, o...)
+		}
+		// Make list order stable
+		sort.Slice(services, func(i, j int) bool {
+			if services[i].Namespace != services[j].Namespace {
+				return services[i].Namespace < services[j].Namespace
+			}
+			return services[i].Name < services[j].Name
+		})
+		return services, nil
+	}
+}
+
+// {{service "name" "namespace"}}
+func service(dm *DependencyManager) func(string, ...string) (*corev1.Service, error) {
+	return func(name string, s ...string) (*corev1.Service, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+		if namespace, err := parseNamespace(dm.namespace, s...); err == nil {
+			return dm.Service(namespace, name)
 		} else {
 			return nil, err
 		}
@@ -64,10 +157,43 @@ func services(dm *DependencyManager) func(...string) ([]corev1.Service, error) {
 }
 
 // {{replicationcontrollers "selector" "namespace"}}
-func replicationcontrollers(dm *DependencyManager) func(...string) ([]corev1.ReplicationController, error) {
-	return func(s ...string) ([]corev1.ReplicationController, error) {
-		if namespace, selector, err := parseNamespaceSelector(s...); err == nil {
-			return dm.ReplicationControllers(namespace, selector)
+// {{replicationcontrollers (dict "selector" "selector" "fields" "fields" "namespace" "namespace")}}
+func replicationcontrollers(dm *DependencyManager) func(...interface{}) ([]corev1.ReplicationController, error) {
+	return func(s ...interface{}) ([]corev1.ReplicationController, error) {
+		namespaces, selector, fieldSelector, err := parseNamespaceSelector(dm.namespace, s...)
+		if err != nil {
+			return nil, err
+		}
+		if len(namespaces) == 1 {
+			return dm.ReplicationControllers(namespaces[0], selector, fieldSelector)
+		}
+		var replicationcontrollers []corev1.ReplicationController
+		for _, namespace := range namespaces {
+			o, err := dm.ReplicationControllers(namespace, selector, fieldSelector)
+			if err != nil {
+				return nil, err
+			}
+			replicationcontrollers = append(replicationcontrollers, o...)
+		}
+		// Make list order stable
+		sort.Slice(replicationcontrollers, func(i, j int) bool {
+			if replicationcontrollers[i].Namespace != replicationcontrollers[j].Namespace {
+				return replicationcontrollers[i].Namespace < replicationcontrollers[j].Namespace
+			}
+			return replicationcontrollers[i].Name < replicationcontrollers[j].Name
+		})
+		return replicationcontrollers, nil
+	}
+}
+
+// {{replicationcontroller "name" "namespace"}}
+func replicationcontroller(dm *DependencyManager) func(string, ...string) (*corev1.ReplicationController, error) {
+	return func(name string, s ...string) (*corev1.ReplicationController, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+		if namespace, err := parseNamespace(dm.namespace, s...); err == nil {
+			return dm.ReplicationController(namespace, name)
 		} else {
 			return nil, err
 		}
@@ -75,10 +201,43 @@ func replicationcontrollers(dm *DependencyManager) func(...string) ([]corev1.Rep
 }
 
 // {{events "selector" "namespace"}}
-func events(dm *DependencyManager) func(...string) ([]corev1.Event, error) {
-	return func(s ...string) ([]corev1.Event, error) {
-		if namespace, selector, err := parseNamespaceSelector(s...); err == nil {
-			return dm.Events(namespace, selector)
+// {{events (dict "selector" "selector" "fields" "fields" "namespace" "namespace")}}
+func events(dm *DependencyManager) func(...interface{}) ([]corev1.Event, error) {
+	return func(s ...interface{}) ([]corev1.Event, error) {
+		namespaces, selector, fieldSelector, err := parseNamespaceSelector(dm.namespace, s...)
+		if err != nil {
+			return nil, err
+		}
+		if len(namespaces) == 1 {
+			return dm.Events(namespaces[0], selector, fieldSelector)
+		}
+		var events []corev1.Event
+		for _, namespace := range namespaces {
+			o, err := dm.Events(namespace, selector, fieldSelector)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, o...)
+		}
+		// Make list order stable
+		sort.Slice(events, func(i, j int) bool {
+			if events[i].Namespace != events[j].Namespace {
+				return events[i].Namespace < events[j].Namespace
+			}
+			return events[i].Name < events[j].Name
+		})
+		return events, nil
+	}
+}
+
+// {{event "name" "namespace"}}
+func event(dm *DependencyManager) func(string, ...string) (*corev1.Event, error) {
+	return func(name string, s ...string) (*corev1.Event, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+		if namespace, err := parseNamespace(dm.namespace, s...); err == nil {
+			return dm.Event(namespace, name)
 		} else {
 			return nil, err
 		}
@@ -86,10 +245,43 @@ func events(dm *DependencyManager) func(...string) ([]corev1.Event, error) {
 }
 
 // {{endpoints "selector" "namespace"}}
-func endpoints(dm *DependencyManager) func(...string) ([]corev1.Endpoints, error) {
-	return func(s ...string) ([]corev1.Endpoints, error) {
-		if namespace, selector, err := parseNamespaceSelector(s...); err == nil {
-			return dm.Endpoints(namespace, selector)
+// {{endpoints (dict "selector" "selector" "fields" "fields" "namespace" "namespace")}}
+func endpoints(dm *DependencyManager) func(...interface{}) ([]corev1.Endpoints, error) {
+	return func(s ...interface{}) ([]corev1.Endpoints, error) {
+		namespaces, selector, fieldSelector, err := parseNamespaceSelector(dm.namespace, s...)
+		if err != nil {
+			return nil, err
+		}
+		if len(namespaces) == 1 {
+			return dm.Endpoints(namespaces[0], selector, fieldSelector)
+		}
+		var endpoints []corev1.Endpoints
+		for _, namespace := range namespaces {
+			o, err := dm.Endpoints(namespace, selector, fieldSelector)
+			if err != nil {
+				return nil, err
+			}
+			endpoints = append(endpoints, o...)
+		}
+		// Make list order stable
+		sort.Slice(endpoints, func(i, j int) bool {
+			if endpoints[i].Namespace != endpoints[j].Namespace {
+				return endpoints[i].Namespace < endpoints[j].Namespace
+			}
+			return endpoints[i].Name < endpoints[j].Name
+		})
+		return endpoints, nil
+	}
+}
+
+// {{endpoint "name" "namespace"}}
+func endpoint(dm *DependencyManager) func(string, ...string) (*corev1.Endpoints, error) {
+	return func(name string, s ...string) (*corev1.Endpoints, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+		if namespace, err := parseNamespace(dm.namespace, s...); err == nil {
+			return dm.Endpoint(namespace, name)
 		} else {
 			return nil, err
 		}
@@ -97,43 +289,109 @@ func endpoints(dm *DependencyManager) func(...string) ([]corev1.Endpoints, error
 }
 
 // {{nodes "selector"}}
-func nodes(dm *DependencyManager) func(...string) ([]corev1.Node, error) {
-	return func(s ...string) ([]corev1.Node, error) {
-		if selector, err := parseSelector(s...); err == nil {
-			return dm.Nodes(selector)
+// {{nodes (dict "selector" "selector" "fields" "fields")}}
+func nodes(dm *DependencyManager) func(...interface{}) ([]corev1.Node, error) {
+	return func(s ...interface{}) ([]corev1.Node, error) {
+		if selector, fieldSelector, err := parseSelector(s...); err == nil {
+			return dm.Nodes(selector, fieldSelector)
 		} else {
 			return nil, err
 		}
 	}
 }
 
+// {{node "name"}}
+func node(dm *DependencyManager) func(string) (*corev1.Node, error) {
+	return func(name string) (*corev1.Node, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+		return dm.Node(name)
+	}
+}
+
 // {{namespaces "selector"}}
-func namespaces(dm *DependencyManager) func(...string) ([]corev1.Namespace, error) {
-	return func(s ...string) ([]corev1.Namespace, error) {
-		if selector, err := parseSelector(s...); err == nil {
-			return dm.Namespaces(selector)
+// {{namespaces (dict "selector" "selector" "fields" "fields")}}
+func namespaces(dm *DependencyManager) func(...interface{}) ([]corev1.Namespace, error) {
+	return func(s ...interface{}) ([]corev1.Namespace, error) {
+		if selector, fieldSelector, err := parseSelector(s...); err == nil {
+			return dm.Namespaces(selector, fieldSelector)
 		} else {
 			return nil, err
 		}
 	}
 }
 
+// {{namespace "name"}}
+func namespace(dm *DependencyManager) func(string) (*corev1.Namespace, error) {
+	return func(name string) (*corev1.Namespace, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+		return dm.Namespace(name)
+	}
+}
+
 // {{componentstatuses "selector"}}
-func componentstatuses(dm *DependencyManager) func(...string) ([]corev1.ComponentStatus, error) {
-	return func(s ...string) ([]corev1.ComponentStatus, error) {
-		if selector, err := parseSelector(s...); err == nil {
-			return dm.ComponentStatuses(selector)
+// {{componentstatuses (dict "selector" "selector" "fields" "fields")}}
+func componentstatuses(dm *DependencyManager) func(...interface{}) ([]corev1.ComponentStatus, error) {
+	return func(s ...interface{}) ([]corev1.ComponentStatus, error) {
+		if selector, fieldSelector, err := parseSelector(s...); err == nil {
+			return dm.ComponentStatuses(selector, fieldSelector)
 		} else {
 			return nil, err
 		}
 	}
 }
 
+// {{componentstatus "name"}}
+func componentstatus(dm *DependencyManager) func(string) (*corev1.ComponentStatus, error) {
+	return func(name string) (*corev1.ComponentStatus, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+		return dm.ComponentStatus(name)
+	}
+}
+
 // {{configmaps "selector" "namespace"}}
-func configmaps(dm *DependencyManager) func(...string) ([]corev1.ConfigMap, error) {
-	return func(s ...string) ([]corev1.ConfigMap, error) {
-		if namespace, selector, err := parseNamespaceSelector(s...); err == nil {
-			return dm.ConfigMaps(namespace, selector)
+// {{configmaps (dict "selector" "selector" "fields" "fields" "namespace" "namespace")}}
+func configmaps(dm *DependencyManager) func(...interface{}) ([]corev1.ConfigMap, error) {
+	return func(s ...interface{}) ([]corev1.ConfigMap, error) {
+		namespaces, selector, fieldSelector, err := parseNamespaceSelector(dm.namespace, s...)
+		if err != nil {
+			return nil, err
+		}
+		if len(namespaces) == 1 {
+			return dm.ConfigMaps(namespaces[0], selector, fieldSelector)
+		}
+		var configmaps []corev1.ConfigMap
+		for _, namespace := range namespaces {
+			o, err := dm.ConfigMaps(namespace, selector, fieldSelector)
+			if err != nil {
+				return nil, err
+			}
+			configmaps = append(configmaps, o...)
+		}
+		// Make list order stable
+		sort.Slice(configmaps, func(i, j int) bool {
+			if configmaps[i].Namespace != configmaps[j].Namespace {
+				return configmaps[i].Namespace < configmaps[j].Namespace
+			}
+			return configmaps[i].Name < configmaps[j].Name
+		})
+		return configmaps, nil
+	}
+}
+
+// {{configmap "name" "namespace"}}
+func configmap(dm *DependencyManager) func(string, ...string) (*corev1.ConfigMap, error) {
+	return func(name string, s ...string) (*corev1.ConfigMap, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+		if namespace, err := parseNamespace(dm.namespace, s...); err == nil {
+			return dm.ConfigMap(namespace, name)
 		} else {
 			return nil, err
 		}
@@ -141,10 +399,43 @@ func configmaps(dm *DependencyManager) func(...string) ([]corev1.ConfigMap, erro
 }
 
 // {{limitranges "selector" "namespace"}}
-func limitranges(dm *DependencyManager) func(...string) ([]corev1.LimitRange, error) {
-	return func(s ...string) ([]corev1.LimitRange, error) {
-		if namespace, selector, err := parseNamespaceSelector(s...); err == nil {
-			return dm.LimitRanges(namespace, selector)
+// {{limitranges (dict "selector" "selector" "fields" "fields" "namespace" "namespace")}}
+func limitranges(dm *DependencyManager) func(...interface{}) ([]corev1.LimitRange, error) {
+	return func(s ...interface{}) ([]corev1.LimitRange, error) {
+		namespaces, selector, fieldSelector, err := parseNamespaceSelector(dm.namespace, s...)
+		if err != nil {
+			return nil, err
+		}
+		if len(namespaces) == 1 {
+			return dm.LimitRanges(namespaces[0], selector, fieldSelector)
+		}
+		var limitranges []corev1.LimitRange
+		for _, namespace := range namespaces {
+			o, err := dm.LimitRanges(namespace, selector, fieldSelector)
+			if err != nil {
+				return nil, err
+			}
+			limitranges = append(limitranges, o...)
+		}
+		// Make list order stable
+		sort.Slice(limitranges, func(i, j int) bool {
+			if limitranges[i].Namespace != limitranges[j].Namespace {
+				return limitranges[i].Namespace < limitranges[j].Namespace
+			}
+			return limitranges[i].Name < limitranges[j].Name
+		})
+		return limitranges, nil
+	}
+}
+
+// {{limitrange "name" "namespace"}}
+func limitrange(dm *DependencyManager) func(string, ...string) (*corev1.LimitRange, error) {
+	return func(name string, s ...string) (*corev1.LimitRange, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+		if namespace, err := parseNamespace(dm.namespace, s...); err == nil {
+			return dm.LimitRange(namespace, name)
 		} else {
 			return nil, err
 		}
@@ -152,21 +443,65 @@ func limitranges(dm *DependencyManager) func(...string) ([]corev1.LimitRange, er
 }
 
 // {{persistentvolumes "selector"}}
-func persistentvolumes(dm *DependencyManager) func(...string) ([]corev1.PersistentVolume, error) {
-	return func(s ...string) ([]corev1.PersistentVolume, error) {
-		if selector, err := parseSelector(s...); err == nil {
-			return dm.PersistentVolumes(selector)
+// {{persistentvolumes (dict "selector" "selector" "fields" "fields")}}
+func persistentvolumes(dm *DependencyManager) func(...interface{}) ([]corev1.PersistentVolume, error) {
+	return func(s ...interface{}) ([]corev1.PersistentVolume, error) {
+		if selector, fieldSelector, err := parseSelector(s...); err == nil {
+			return dm.PersistentVolumes(selector, fieldSelector)
 		} else {
 			return nil, err
 		}
 	}
 }
 
+// {{persistentvolume "name"}}
+func persistentvolume(dm *DependencyManager) func(string) (*corev1.PersistentVolume, error) {
+	return func(name string) (*corev1.PersistentVolume, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+		return dm.PersistentVolume(name)
+	}
+}
+
 // {{persistentvolumeclaims "selector" "namespace"}}
-func persistentvolumeclaims(dm *DependencyManager) func(...string) ([]corev1.PersistentVolumeClaim, error) {
-	return func(s ...string) ([]corev1.PersistentVolumeClaim, error) {
-		if namespace, selector, err := parseNamespaceSelector(s...); err == nil {
-			return dm.PersistentVolumeClaims(namespace, selector)
+// {{persistentvolumeclaims (dict "selector" "selector" "fields" "fields" "namespace" "namespace")}}
+func persistentvolumeclaims(dm *DependencyManager) func(...interface{}) ([]corev1.PersistentVolumeClaim, error) {
+	return func(s ...interface{}) ([]corev1.PersistentVolumeClaim, error) {
+		namespaces, selector, fieldSelector, err := parseNamespaceSelector(dm.namespace, s...)
+		if err != nil {
+			return nil, err
+		}
+		if len(namespaces) == 1 {
+			return dm.PersistentVolumeClaims(namespaces[0], selector, fieldSelector)
+		}
+		var persistentvolumeclaims []corev1.PersistentVolumeClaim
+		for _, namespace := range namespaces {
+			o, err := dm.PersistentVolumeClaims(namespace, selector, fieldSelector)
+			if err != nil {
+				return nil, err
+			}
+			persistentvolumeclaims = append(persistentvolumeclaims, o...)
+		}
+		// Make list order stable
+		sort.Slice(persistentvolumeclaims, func(i, j int) bool {
+			if persistentvolumeclaims[i].Namespace != persistentvolumeclaims[j].Namespace {
+				return persistentvolumeclaims[i].Namespace < persistentvolumeclaims[j].Namespace
+			}
+			return persistentvolumeclaims[i].Name < persistentvolumeclaims[j].Name
+		})
+		return persistentvolumeclaims, nil
+	}
+}
+
+// {{persistentvolumeclaim "name" "namespace"}}
+func persistentvolumeclaim(dm *DependencyManager) func(string, ...string) (*corev1.PersistentVolumeClaim, error) {
+	return func(name string, s ...string) (*corev1.PersistentVolumeClaim, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+		if namespace, err := parseNamespace(dm.namespace, s...); err == nil {
+			return dm.PersistentVolumeClaim(namespace, name)
 		} else {
 			return nil, err
 		}
@@ -174,10 +509,43 @@ func persistentvolumeclaims(dm *DependencyManager) func(...string) ([]corev1.Per
 }
 
 // {{podtemplates "selector" "namespace"}}
-func podtemplates(dm *DependencyManager) func(...string) ([]corev1.PodTemplate, error) {
-	return func(s ...string) ([]corev1.PodTemplate, error) {
-		if namespace, selector, err := parseNamespaceSelector(s...); err == nil {
-			return dm.PodTemplates(namespace, selector)
+// {{podtemplates (dict "selector" "selector" "fields" "fields" "namespace" "namespace")}}
+func podtemplates(dm *DependencyManager) func(...interface{}) ([]corev1.PodTemplate, error) {
+	return func(s ...interface{}) ([]corev1.PodTemplate, error) {
+		namespaces, selector, fieldSelector, err := parseNamespaceSelector(dm.namespace, s...)
+		if err != nil {
+			return nil, err
+		}
+		if len(namespaces) == 1 {
+			return dm.PodTemplates(namespaces[0], selector, fieldSelector)
+		}
+		var podtemplates []corev1.PodTemplate
+		for _, namespace := range namespaces {
+			o, err := dm.PodTemplates(namespace, selector, fieldSelector)
+			if err != nil {
+				return nil, err
+			}
+			podtemplates = append(podtemplates, o...)
+		}
+		// Make list order stable
+		sort.Slice(podtemplates, func(i, j int) bool {
+			if podtemplates[i].Namespace != podtemplates[j].Namespace {
+				return podtemplates[i].Namespace < podtemplates[j].Namespace
+			}
+			return podtemplates[i].Name < podtemplates[j].Name
+		})
+		return podtemplates, nil
+	}
+}
+
+// {{podtemplate "name" "namespace"}}
+func podtemplate(dm *DependencyManager) func(string, ...string) (*corev1.PodTemplate, error) {
+	return func(name string, s ...string) (*corev1.PodTemplate, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+		if namespace, err := parseNamespace(dm.namespace, s...); err == nil {
+			return dm.PodTemplate(namespace, name)
 		} else {
 			return nil, err
 		}
@@ -185,10 +553,43 @@ func podtemplates(dm *DependencyManager) func(...string) ([]corev1.PodTemplate,
 }
 
 // {{resourcequotas "selector" "namespace"}}
-func resourcequotas(dm *DependencyManager) func(...string) ([]corev1.ResourceQuota, error) {
-	return func(s ...string) ([]corev1.ResourceQuota, error) {
-		if namespace, selector, err := parseNamespaceSelector(s...); err == nil {
-			return dm.ResourceQuotas(namespace, selector)
+// {{resourcequotas (dict "selector" "selector" "fields" "fields" "namespace" "namespace")}}
+func resourcequotas(dm *DependencyManager) func(...interface{}) ([]corev1.ResourceQuota, error) {
+	return func(s ...interface{}) ([]corev1.ResourceQuota, error) {
+		namespaces, selector, fieldSelector, err := parseNamespaceSelector(dm.namespace, s...)
+		if err != nil {
+			return nil, err
+		}
+		if len(namespaces) == 1 {
+			return dm.ResourceQuotas(namespaces[0], selector, fieldSelector)
+		}
+		var resourcequotas []corev1.ResourceQuota
+		for _, namespace := range namespaces {
+			o, err := dm.ResourceQuotas(namespace, selector, fieldSelector)
+			if err != nil {
+				return nil, err
+			}
+			resourcequotas = append(resourcequotas, o...)
+		}
+		// Make list order stable
+		sort.Slice(resourcequotas, func(i, j int) bool {
+			if resourcequotas[i].Namespace != resourcequotas[j].Namespace {
+				return resourcequotas[i].Namespace < resourcequotas[j].Namespace
+			}
+			return resourcequotas[i].Name < resourcequotas[j].Name
+		})
+		return resourcequotas, nil
+	}
+}
+
+// {{resourcequota "name" "namespace"}}
+func resourcequota(dm *DependencyManager) func(string, ...string) (*corev1.ResourceQuota, error) {
+	return func(name string, s ...string) (*corev1.ResourceQuota, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+		if namespace, err := parseNamespace(dm.namespace, s...); err == nil {
+			return dm.ResourceQuota(namespace, name)
 		} else {
 			return nil, err
 		}
@@ -196,10 +597,43 @@ func resourcequotas(dm *DependencyManager) func(...string) ([]corev1.ResourceQuo
 }
 
 // {{secrets "selector" "namespace"}}
-func secrets(dm *DependencyManager) func(...string) ([]corev1.Secret, error) {
-	return func(s ...string) ([]corev1.Secret, error) {
-		if namespace, selector, err := parseNamespaceSelector(s...); err == nil {
-			return dm.Secrets(namespace, selector)
+// {{secrets (dict "selector" "selector" "fields" "fields" "namespace" "namespace")}}
+func secrets(dm *DependencyManager) func(...interface{}) ([]corev1.Secret, error) {
+	return func(s ...interface{}) ([]corev1.Secret, error) {
+		namespaces, selector, fieldSelector, err := parseNamespaceSelector(dm.namespace, s...)
+		if err != nil {
+			return nil, err
+		}
+		if len(namespaces) == 1 {
+			return dm.Secrets(namespaces[0], selector, fieldSelector)
+		}
+		var secrets []corev1.Secret
+		for _, namespace := range namespaces {
+			o, err := dm.Secrets(namespace, selector, fieldSelector)
+			if err != nil {
+				return nil, err
+			}
+			secrets = append(secrets, o...)
+		}
+		// Make list order stable
+		sort.Slice(secrets, func(i, j int) bool {
+			if secrets[i].Namespace != secrets[j].Namespace {
+				return secrets[i].Namespace < secrets[j].Namespace
+			}
+			return secrets[i].Name < secrets[j].Name
+		})
+		return secrets, nil
+	}
+}
+
+// {{secret "name" "namespace"}}
+func secret(dm *DependencyManager) func(string, ...string) (*corev1.Secret, error) {
+	return func(name string, s ...string) (*corev1.Secret, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+		if namespace, err := parseNamespace(dm.namespace, s...); err == nil {
+			return dm.Secret(namespace, name)
 		} else {
 			return nil, err
 		}
@@ -207,10 +641,219 @@ func secrets(dm *DependencyManager) func(...string) ([]corev1.Secret, error) {
 }
 
 // {{serviceaccounts "selector" "namespace"}}
-func serviceaccounts(dm *DependencyManager) func(...string) ([]corev1.ServiceAccount, error) {
-	return func(s ...string) ([]corev1.ServiceAccount, error) {
-		if namespace, selector, err := parseNamespaceSelector(s...); err == nil {
-			return dm.ServiceAccounts(namespace, selector)
+// {{serviceaccounts (dict "selector" "selector" "fields" "fields" "namespace" "namespace")}}
+func serviceaccounts(dm *DependencyManager) func(...interface{}) ([]corev1.ServiceAccount, error) {
+	return func(s ...interface{}) ([]corev1.ServiceAccount, error) {
+		namespaces, selector, fieldSelector, err := parseNamespaceSelector(dm.namespace, s...)
+		if err != nil {
+			return nil, err
+		}
+		if len(namespaces) == 1 {
+			return dm.ServiceAccounts(namespaces[0], selector, fieldSelector)
+		}
+		var serviceaccounts []corev1.ServiceAccount
+		for _, namespace := range namespaces {
+			o, err := dm.ServiceAccounts(namespace, selector, fieldSelector)
+			if err != nil {
+				return nil, err
+			}
+			serviceaccounts = append(serviceaccounts, o...)
+		}
+		// Make list order stable
+		sort.Slice(serviceaccounts, func(i, j int) bool {
+			if serviceaccounts[i].Namespace != serviceaccounts[j].Namespace {
+				return serviceaccounts[i].Namespace < serviceaccounts[j].Namespace
+			}
+			return serviceaccounts[i].Name < serviceaccounts[j].Name
+		})
+		return serviceaccounts, nil
+	}
+}
+
+// {{serviceaccount "name" "namespace"}}
+func serviceaccount(dm *DependencyManager) func(string, ...string) (*corev1.ServiceAccount, error) {
+	return func(name string, s ...string) (*corev1.ServiceAccount, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+		if namespace, err := parseNamespace(dm.namespace, s...); err == nil {
+			return dm.ServiceAccount(namespace, name)
+		} else {
+			return nil, err
+		}
+	}
+}
+
+// {{deployments "selector" "namespace"}}
+// {{deployments (dict "selector" "selector" "fields" "fields" "namespace" "namespace")}}
+func deployments(dm *DependencyManager) func(...interface{}) ([]appsv1.Deployment, error) {
+	return func(s ...interface{}) ([]appsv1.Deployment, error) {
+		namespaces, selector, fieldSelector, err := parseNamespaceSelector(dm.namespace, s...)
+		if err != nil {
+			return nil, err
+		}
+		if len(namespaces) == 1 {
+			return dm.Deployments(namespaces[0], selector, fieldSelector)
+		}
+		var deployments []appsv1.Deployment
+		for _, namespace := range namespaces {
+			o, err := dm.Deployments(namespace, selector, fieldSelector)
+			if err != nil {
+				return nil, err
+			}
+			deployments = append(deployments, o...)
+		}
+		// Make list order stable
+		sort.Slice(deployments, func(i, j int) bool {
+			if deployments[i].Namespace != deployments[j].Namespace {
+				return deployments[i].Namespace < deployments[j].Namespace
+			}
+			return deployments[i].Name < deployments[j].Name
+		})
+		return deployments, nil
+	}
+}
+
+// {{deployment "name" "namespace"}}
+func deployment(dm *DependencyManager) func(string, ...string) (*appsv1.Deployment, error) {
+	return func(name string, s ...string) (*appsv1.Deployment, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+		if namespace, err := parseNamespace(dm.namespace, s...); err == nil {
+			return dm.Deployment(namespace, name)
+		} else {
+			return nil, err
+		}
+	}
+}
+
+// {{statefulsets "selector" "namespace"}}
+// {{statefulsets (dict "selector" "selector" "fields" "fields" "namespace" "namespace")}}
+func statefulsets(dm *DependencyManager) func(...interface{}) ([]appsv1.StatefulSet, error) {
+	return func(s ...interface{}) ([]appsv1.StatefulSet, error) {
+		namespaces, selector, fieldSelector, err := parseNamespaceSelector(dm.namespace, s...)
+		if err != nil {
+			return nil, err
+		}
+		if len(namespaces) == 1 {
+			return dm.StatefulSets(namespaces[0], selector, fieldSelector)
+		}
+		var statefulsets []appsv1.StatefulSet
+		for _, namespace := range namespaces {
+			o, err := dm.StatefulSets(namespace, selector, fieldSelector)
+			if err != nil {
+				return nil, err
+			}
+			statefulsets = append(statefulsets, o...)
+		}
+		// Make list order stable
+		sort.Slice(statefulsets, func(i, j int) bool {
+			if statefulsets[i].Namespace != statefulsets[j].Namespace {
+				return statefulsets[i].Namespace < statefulsets[j].Namespace
+			}
+			return statefulsets[i].Name < statefulsets[j].Name
+		})
+		return statefulsets, nil
+	}
+}
+
+// {{statefulset "name" "namespace"}}
+func statefulset(dm *DependencyManager) func(string, ...string) (*appsv1.StatefulSet, error) {
+	return func(name string, s ...string) (*appsv1.StatefulSet, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+		if namespace, err := parseNamespace(dm.namespace, s...); err == nil {
+			return dm.StatefulSet(namespace, name)
+		} else {
+			return nil, err
+		}
+	}
+}
+
+// {{daemonsets "selector" "namespace"}}
+// {{daemonsets (dict "selector" "selector" "fields" "fields" "namespace" "namespace")}}
+func daemonsets(dm *DependencyManager) func(...interface{}) ([]appsv1.DaemonSet, error) {
+	return func(s ...interface{}) ([]appsv1.DaemonSet, error) {
+		namespaces, selector, fieldSelector, err := parseNamespaceSelector(dm.namespace, s...)
+		if err != nil {
+			return nil, err
+		}
+		if len(namespaces) == 1 {
+			return dm.DaemonSets(namespaces[0], selector, fieldSelector)
+		}
+		var daemonsets []appsv1.DaemonSet
+		for _, namespace := range namespaces {
+			o, err := dm.DaemonSets(namespace, selector, fieldSelector)
+			if err != nil {
+				return nil, err
+			}
+			daemonsets = append(daemonsets, o...)
+		}
+		// Make list order stable
+		sort.Slice(daemonsets, func(i, j int) bool {
+			if daemonsets[i].Namespace != daemonsets[j].Namespace {
+				return daemonsets[i].Namespace < daemonsets[j].Namespace
+			}
+			return daemonsets[i].Name < daemonsets[j].Name
+		})
+		return daemonsets, nil
+	}
+}
+
+// {{daemonset "name" "namespace"}}
+func daemonset(dm *DependencyManager) func(string, ...string) (*appsv1.DaemonSet, error) {
+	return func(name string, s ...string) (*appsv1.DaemonSet, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+		if namespace, err := parseNamespace(dm.namespace, s...); err == nil {
+			return dm.DaemonSet(namespace, name)
+		} else {
+			return nil, err
+		}
+	}
+}
+
+// {{replicasets "selector" "namespace"}}
+// {{replicasets (dict "selector" "selector" "fields" "fields" "namespace" "namespace")}}
+func replicasets(dm *DependencyManager) func(...interface{}) ([]appsv1.ReplicaSet, error) {
+	return func(s ...interface{}) ([]appsv1.ReplicaSet, error) {
+		namespaces, selector, fieldSelector, err := parseNamespaceSelector(dm.namespace, s...)
+		if err != nil {
+			return nil, err
+		}
+		if len(namespaces) == 1 {
+			return dm.ReplicaSets(namespaces[0], selector, fieldSelector)
+		}
+		var replicasets []appsv1.ReplicaSet
+		for _, namespace := range namespaces {
+			o, err := dm.ReplicaSets(namespace, selector, fieldSelector)
+			if err != nil {
+				return nil, err
+			}
+			replicasets = append(replicasets, o...)
+		}
+		// Make list order stable
+		sort.Slice(replicasets, func(i, j int) bool {
+			if replicasets[i].Namespace != replicasets[j].Namespace {
+				return replicasets[i].Namespace < replicasets[j].Namespace
+			}
+			return replicasets[i].Name < replicasets[j].Name
+		})
+		return replicasets, nil
+	}
+}
+
+// {{replicaset "name" "namespace"}}
+func replicaset(dm *DependencyManager) func(string, ...string) (*appsv1.ReplicaSet, error) {
+	return func(name string, s ...string) (*appsv1.ReplicaSet, error) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+		if namespace, err := parseNamespace(dm.namespace, s...); err == nil {
+			return dm.ReplicaSet(namespace, name)
 		} else {
 			return nil, err
 		}
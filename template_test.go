@@ -3,6 +3,17 @@ package main
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	gotemplate "text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/kubernetes/pkg/controller/testutil"
 
@@ -21,7 +32,7 @@ func TestTemplateRender(t *testing.T) {
 	stopCh := make(chan struct{})
 	defer close(stopCh)
 
-	tc, err := newClient(fakeClient, stopCh, true)
+	tc, err := newClient(fakeClient, nil, stopCh, true)
 	require.NoError(t, err)
 
 	dm := newDependencyManager(tc)
@@ -45,3 +56,292 @@ func TestTemplateRender(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, string(expected), actual)
 }
+
+func TestTemplateAffectedBy(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset(testutil.NewPod("pod1", "host1"))
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	tc, err := newClient(fakeClient, nil, stopCh, false)
+	require.NoError(t, err)
+
+	dm := newDependencyManager(tc)
+
+	template := &Template{
+		name:     "test",
+		template: gotemplate.Must(gotemplate.New("test").Funcs(funcMap(dm)).Parse(`{{range pods "name=pod1"}}{{.Name}}{{end}}`)),
+		dm:       dm,
+	}
+	// Dependencies are unknown before first rendering
+	require.True(t, template.affectedBy(nil))
+
+	_, err = template.Render()
+	require.NoError(t, err)
+
+	require.True(t, template.affectedBy([]objectChange{
+		{resource: "pods", namespace: DefaultNamespace, labels: labels.Set{"name": "pod1"}},
+	}))
+	require.False(t, template.affectedBy([]objectChange{
+		{resource: "pods", namespace: DefaultNamespace, labels: labels.Set{"name": "pod2"}},
+		{resource: "services", namespace: DefaultNamespace, labels: labels.Set{"name": "pod1"}},
+	}))
+}
+
+func TestTemplateResources(t *testing.T) {
+	fakeDynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(),
+		newTestCertificate("cert1", "ns1", "secret1"))
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	tc, err := newClient(fake.NewSimpleClientset(), fakeDynamicClient, stopCh, false)
+	require.NoError(t, err)
+
+	dm := newDependencyManager(tc)
+
+	template := &Template{
+		name: "test",
+		template: gotemplate.Must(gotemplate.New("test").Funcs(funcMap(dm)).Parse(
+			`{{range resources "cert-manager.io/v1/certificates" "" "ns1"}}{{.GetName}}:{{.Object.spec.secretName}}{{end}}`)),
+		dm: dm,
+	}
+
+	actual, err := template.Render()
+	require.NoError(t, err)
+	require.Equal(t, "cert1:secret1", actual)
+	require.True(t, template.deps[dependencyKey{resource: "cert-manager.io/v1/certificates", namespace: "ns1"}])
+}
+
+func TestParseGroupVersionResource(t *testing.T) {
+	gvr, err := parseGroupVersionResource("cert-manager.io/v1/Certificates")
+	require.NoError(t, err)
+	require.Equal(t, schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}, gvr)
+
+	gvr, err = parseGroupVersionResource("v1/configmaps")
+	require.NoError(t, err)
+	require.Equal(t, schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, gvr)
+
+	_, err = parseGroupVersionResource("configmaps")
+	require.Error(t, err)
+
+	_, err = parseGroupVersionResource("apps//deployments")
+	require.Error(t, err)
+}
+
+func TestParseNamespaceSelector(t *testing.T) {
+	namespaces, selector, fieldSelector, err := parseNamespaceSelector(DefaultNamespace)
+	require.NoError(t, err)
+	require.Equal(t, []string{DefaultNamespace}, namespaces)
+	require.Equal(t, []string{DefaultSelector, DefaultFieldSelector}, []string{selector, fieldSelector})
+
+	namespaces, selector, fieldSelector, err = parseNamespaceSelector(DefaultNamespace, "name=pod1", "ns1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"ns1"}, namespaces)
+	require.Equal(t, []string{"name=pod1", ""}, []string{selector, fieldSelector})
+
+	namespaces, selector, fieldSelector, err = parseNamespaceSelector("ns0", map[string]interface{}{
+		"selector": "name=pod1",
+		"fields":   "spec.nodeName=host1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"ns0"}, namespaces)
+	require.Equal(t, []string{"name=pod1", "spec.nodeName=host1"}, []string{selector, fieldSelector})
+
+	_, _, _, err = parseNamespaceSelector(DefaultNamespace, "a", "b", "c")
+	require.Error(t, err)
+
+	_, _, _, err = parseNamespaceSelector(DefaultNamespace, map[string]interface{}{"unknown": "value"})
+	require.Error(t, err)
+
+	_, _, _, err = parseNamespaceSelector(DefaultNamespace, map[string]interface{}{"fields": 1})
+	require.Error(t, err)
+
+	_, _, err = parseSelector(map[string]interface{}{"namespace": "ns1"})
+	require.Error(t, err)
+}
+
+func TestTemplateSingleObject(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm1", Namespace: "ns1"},
+		Data:       map[string]string{"key": "value"},
+	}
+	fakeClient := fake.NewSimpleClientset(configMap, testutil.NewPod("pod1", "host1"))
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	tc, err := newClient(fakeClient, nil, stopCh, true)
+	require.NoError(t, err)
+
+	dm := newDependencyManager(tc)
+
+	template := &Template{
+		name: "test",
+		template: gotemplate.Must(gotemplate.New("test").Funcs(funcMap(dm)).Parse(
+			`{{(configmap "cm1" "ns1").Data.key}} {{(pod "pod1").Spec.NodeName}} {{with configmap "unknown" "ns1"}}found{{else}}not found{{end}}`)),
+		dm: dm,
+	}
+
+	actual, err := template.Render()
+	require.NoError(t, err)
+	require.Equal(t, "value host1 not found", actual)
+}
+
+func TestParseNamespaces(t *testing.T) {
+	namespaces, err := toNamespaces("ns1, ns2,ns1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"ns1", "ns2"}, namespaces)
+
+	namespaces, err = toNamespaces([]interface{}{"ns1", "ns2"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"ns1", "ns2"}, namespaces)
+
+	namespaces, err = toNamespaces("ns1,*")
+	require.NoError(t, err)
+	require.Equal(t, []string{metav1.NamespaceAll}, namespaces)
+
+	namespaces, err = toNamespaces("")
+	require.NoError(t, err)
+	require.Equal(t, []string{metav1.NamespaceAll}, namespaces)
+
+	_, err = toNamespaces(1)
+	require.Error(t, err)
+
+	_, err = parseNamespace(DefaultNamespace, "*")
+	require.Error(t, err)
+
+	_, err = parseNamespace(DefaultNamespace, "ns1,ns2")
+	require.Error(t, err)
+
+	_, err = parseNamespace(DefaultNamespace, "")
+	require.EqualError(t, err, `expected single namespace, got ""`)
+
+	namespace, err := parseNamespace(DefaultNamespace)
+	require.NoError(t, err)
+	require.Equal(t, DefaultNamespace, namespace)
+}
+
+func TestTemplateMultipleNamespaces(t *testing.T) {
+	newPod := func(name, namespace string) *corev1.Pod {
+		pod := testutil.NewPod(name, "host1")
+		pod.Namespace = namespace
+		return pod
+	}
+	fakeClient := fake.NewSimpleClientset(newPod("pod2", "ns2"), newPod("pod1", "ns2"),
+		newPod("pod3", "ns1"), newPod("pod4", "ns3"))
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	tc, err := newClient(fakeClient, nil, stopCh, true)
+	require.NoError(t, err)
+
+	dm := newDependencyManager(tc)
+	dm.namespace = "ns3"
+
+	template := &Template{
+		name: "test",
+		template: gotemplate.Must(gotemplate.New("test").Funcs(funcMap(dm)).Parse(
+			`{{range pods "" "ns2,ns1"}}{{.Namespace}}/{{.Name}} {{end}}|` +
+				`{{range pods (dict "namespace" (list "ns3" "ns1"))}}{{.Namespace}}/{{.Name}} {{end}}|` +
+				`{{range pods "" "*"}}{{.Namespace}}/{{.Name}} {{end}}|` +
+				`{{range pods}}{{.Namespace}}/{{.Name}} {{end}}`)),
+		dm: dm,
+	}
+
+	actual, err := template.Render()
+	require.NoError(t, err)
+	require.Equal(t, "ns1/pod3 ns2/pod1 ns2/pod2 |ns1/pod3 ns3/pod4 |"+
+		"ns1/pod3 ns2/pod1 ns2/pod2 ns3/pod4 |ns3/pod4 ", actual)
+}
+
+func TestTemplateWrite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kube-template")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	template := filepath.Join(dir, "in.tmpl")
+	require.NoError(t, ioutil.WriteFile(template, []byte("test"), 0644))
+
+	d := &TemplateDescriptor{
+		Path:   template,
+		Output: filepath.Join(dir, "out", "tls.key"),
+		Perms:  0600,
+		User:   strconv.Itoa(os.Getuid()),
+		Group:  strconv.Itoa(os.Getgid()),
+	}
+	tmpl, err := newTemplate(&Config{LeftDelimiter: "{{", RightDelimiter: "}}"}, nil, d)
+	require.NoError(t, err)
+
+	for _, content := range []string{"first", "second"} {
+		require.NoError(t, tmpl.Write([]byte(content)))
+
+		actual, err := ioutil.ReadFile(d.Output)
+		require.NoError(t, err)
+		require.Equal(t, content, string(actual))
+
+		fi, err := os.Stat(d.Output)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+	}
+
+	// No temp files are left
+	files, err := ioutil.ReadDir(filepath.Dir(d.Output))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
+
+func TestTemplateWriteCheck(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kube-template")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	template := filepath.Join(dir, "in.tmpl")
+	require.NoError(t, ioutil.WriteFile(template, []byte("test"), 0644))
+
+	// Output file names with spaces and shell special characters are quoted
+	d := &TemplateDescriptor{
+		Path:   template,
+		Output: filepath.Join(dir, "my out;dir", "out.txt"),
+		Check:  "grep -q valid {{.TempFile}} && test ! -e {{.Output}} -o -f {{.Output}}",
+	}
+	tmpl, err := newTemplate(&Config{LeftDelimiter: "{{", RightDelimiter: "}}"}, nil, d)
+	require.NoError(t, err)
+
+	require.NoError(t, tmpl.Write([]byte("valid")))
+	require.Error(t, tmpl.Write([]byte("broken")))
+
+	actual, err := ioutil.ReadFile(d.Output)
+	require.NoError(t, err)
+	require.Equal(t, "valid", string(actual))
+}
+
+func TestTemplateRollback(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kube-template")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	template := filepath.Join(dir, "in.tmpl")
+	require.NoError(t, ioutil.WriteFile(template, []byte("test"), 0644))
+
+	d := &TemplateDescriptor{
+		Path:   template,
+		Output: filepath.Join(dir, "out.txt"),
+	}
+	tmpl, err := newTemplate(&Config{LeftDelimiter: "{{", RightDelimiter: "}}"}, nil, d)
+	require.NoError(t, err)
+
+	// Output file didn't exist before first write
+	require.NoError(t, tmpl.Write([]byte("first")))
+	require.NoError(t, tmpl.Rollback())
+	_, err = os.Stat(d.Output)
+	require.True(t, os.IsNotExist(err))
+
+	require.NoError(t, tmpl.Write([]byte("first")))
+	require.NoError(t, tmpl.Write([]byte("second")))
+	require.NoError(t, tmpl.Rollback())
+	actual, err := ioutil.ReadFile(d.Output)
+	require.NoError(t, err)
+	require.Equal(t, "first", string(actual))
+}
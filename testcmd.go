@@ -0,0 +1,201 @@
+// Copyright © 2015 Victor Antonovich <victor@antonovich.me>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagUpdate = "update"
+)
+
+const (
+	TestTemplateExt = ".template"
+	TestGoldenExt   = ".out.golden"
+	TestFixturesExt = ".fixtures"
+)
+
+// Template test case, discovered by template file name
+type TemplateTest struct {
+	// Test case name
+	Name string
+	// Template file path
+	Template string
+	// Fixtures file or directory path, empty if not present
+	Fixtures string
+	// Expected output (golden) file path
+	Golden string
+}
+
+func newTestCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "test [path...]",
+		Short: "Run template tests",
+		Long: `Renders test templates offline using Kubernetes objects from fixture manifests and compares
+results with expected output. For each '<name>` + TestTemplateExt + `' template file found at given paths
+(current directory by default), Kubernetes objects are loaded from optional '<name>` + TestFixturesExt + `.(yaml|yml|json)'
+file or '<name>` + TestFixturesExt + `' directory, and expected output is read from '<name>` + TestGoldenExt + `' file.`,
+		Run: runTestCmd,
+	}
+	cmd.Flags().Bool(FlagUpdate, false, "update expected output files with rendered templates")
+	return cmd
+}
+
+func runTestCmd(cmd *cobra.Command, args []string) {
+	config, err := newConfig(cmd)
+	if err != nil {
+		glog.Fatalf("config error: %v, exiting...", err)
+	}
+
+	update, _ := cmd.Flags().GetBool(FlagUpdate)
+
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+	tests, err := findTemplateTests(args)
+	if err != nil {
+		glog.Fatalf("can't find template tests: %v", err)
+	}
+	if len(tests) == 0 {
+		glog.Fatalf("no template tests found")
+	}
+
+	if failed := runTemplateTests(config, tests, update, os.Stdout); failed > 0 {
+		flushLogs()
+		os.Exit(1)
+	}
+}
+
+// Find template tests at given paths
+func findTemplateTests(paths []string) ([]*TemplateTest, error) {
+	var tests []*TemplateTest
+	for _, path := range paths {
+		err := filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || !strings.HasSuffix(p, TestTemplateExt) {
+				return nil
+			}
+			prefix := strings.TrimSuffix(p, TestTemplateExt)
+			test := &TemplateTest{
+				Name:     filepath.Base(prefix),
+				Template: p,
+				Golden:   prefix + TestGoldenExt,
+			}
+			for _, ext := range []string{"", ".yaml", ".yml", ".json"} {
+				f := prefix + TestFixturesExt + ext
+				if _, err := os.Stat(f); err == nil {
+					test.Fixtures = f
+					break
+				}
+			}
+			tests = append(tests, test)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(tests, func(i, j int) bool {
+		return tests[i].Template < tests[j].Template
+	})
+	return tests, nil
+}
+
+// Run given template tests, writing results to given writer. Returns number of failed tests.
+func runTemplateTests(cfg *Config, tests []*TemplateTest, update bool, w io.Writer) int {
+	failed := 0
+	for _, test := range tests {
+		actual, err := test.render(cfg)
+		if err != nil {
+			failed++
+			fmt.Fprintf(w, "--- FAIL: %s\n    %v\n", test.Name, err)
+			continue
+		}
+		if update {
+			if err := ioutil.WriteFile(test.Golden, []byte(actual), 0644); err != nil {
+				failed++
+				fmt.Fprintf(w, "--- FAIL: %s\n    %v\n", test.Name, err)
+				continue
+			}
+			fmt.Fprintf(w, "--- UPDATED: %s\n", test.Name)
+			continue
+		}
+		expected, err := ioutil.ReadFile(test.Golden)
+		if err != nil {
+			failed++
+			fmt.Fprintf(w, "--- FAIL: %s\n    %v\n", test.Name, err)
+			continue
+		}
+		if string(expected) == actual {
+			fmt.Fprintf(w, "--- PASS: %s\n", test.Name)
+			continue
+		}
+		failed++
+		fmt.Fprintf(w, "--- FAIL: %s\n", test.Name)
+		diff, err := unifiedDiff(string(expected), actual, test.Golden, test.Template)
+		if err != nil {
+			fmt.Fprintf(w, "    %v\n", err)
+			continue
+		}
+		fmt.Fprint(w, diff)
+	}
+	if failed > 0 {
+		fmt.Fprintf(w, "FAIL: %d of %d template test(s) failed\n", failed, len(tests))
+	} else {
+		fmt.Fprintf(w, "PASS: %d template test(s)\n", len(tests))
+	}
+	return failed
+}
+
+// Render test template using Kubernetes objects from test fixtures
+func (test *TemplateTest) render(cfg *Config) (string, error) {
+	fixtures := &Fixtures{}
+	if test.Fixtures != "" {
+		var err error
+		if fixtures, err = loadFixtures(test.Fixtures, cfg.Namespace); err != nil {
+			return "", err
+		}
+	}
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	client, err := newClientForFixtures(fixtures, stopCh, false)
+	if err != nil {
+		return "", err
+	}
+
+	dm := newDependencyManager(client)
+	dm.strict = cfg.Strict
+	dm.namespace = cfg.Namespace
+
+	t, err := newTemplate(cfg, dm, &TemplateDescriptor{Path: test.Template, Output: test.Golden})
+	if err != nil {
+		return "", err
+	}
+	return t.Render()
+}
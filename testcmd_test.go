@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testTemplateTestsConfig() *Config {
+	return &Config{
+		LeftDelimiter:  "{{",
+		RightDelimiter: "}}",
+		Namespace:      metav1.NamespaceDefault,
+	}
+}
+
+func TestRunTemplateTests(t *testing.T) {
+	tests, err := findTemplateTests([]string{testDataFilePrefix(t)})
+	require.NoError(t, err)
+	require.Len(t, tests, 1)
+	require.Equal(t, "upstream", tests[0].Name)
+	require.Equal(t, filepath.Join(testDataFilePrefix(t), "upstream.fixtures.yaml"), tests[0].Fixtures)
+
+	out := new(bytes.Buffer)
+	failed := runTemplateTests(testTemplateTestsConfig(), tests, false, out)
+	require.Equal(t, 0, failed, out.String())
+	require.Contains(t, out.String(), "--- PASS: upstream")
+}
+
+func TestRunTemplateTestsFieldSelector(t *testing.T) {
+	tests, err := findTemplateTests([]string{testDataFilePrefix(t)})
+	require.NoError(t, err)
+	require.Len(t, tests, 1)
+
+	// Only pods on selected node are rendered
+	out := new(bytes.Buffer)
+	failed := runTemplateTests(testTemplateTestsConfig(), tests, false, out)
+	require.Equal(t, 0, failed, out.String())
+	require.Contains(t, out.String(), "--- PASS: node")
+}
+
+func TestRunTemplateTestsFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kube-template")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	template := filepath.Join(dir, "pods.template")
+	golden := filepath.Join(dir, "pods.out.golden")
+	require.NoError(t, ioutil.WriteFile(template, []byte("pods: {{len (pods)}}\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(golden, []byte("pods: 1\n"), 0644))
+
+	tests, err := findTemplateTests([]string{dir})
+	require.NoError(t, err)
+	require.Len(t, tests, 1)
+	require.Empty(t, tests[0].Fixtures)
+
+	out := new(bytes.Buffer)
+	failed := runTemplateTests(testTemplateTestsConfig(), tests, false, out)
+	require.Equal(t, 1, failed)
+	require.Contains(t, out.String(), "--- FAIL: pods")
+	require.Contains(t, out.String(), "-pods: 1\n+pods: 0\n")
+
+	out.Reset()
+	failed = runTemplateTests(testTemplateTestsConfig(), tests, true, out)
+	require.Equal(t, 0, failed)
+	actual, err := ioutil.ReadFile(golden)
+	require.NoError(t, err)
+	require.Equal(t, "pods: 0\n", string(actual))
+
+	out.Reset()
+	failed = runTemplateTests(testTemplateTestsConfig(), tests, false, out)
+	require.Equal(t, 0, failed, out.String())
+}
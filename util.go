@@ -16,12 +16,16 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
+	"os/user"
 	"path/filepath"
 	"runtime"
-	"sync"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -51,16 +55,150 @@ func IsPresent(l []string, s string) bool {
 	return false
 }
 
-// Execute command using system shell with timeout
-func Execute(command string, timeout time.Duration) error {
-	// Set shell and command execution flag
-	shell, flag := "/bin/sh", "-c"
-	if runtime.GOOS == "windows" {
-		shell, flag = "cmd", "/C"
+// Lookup user id by given user name or id, returns -1 for empty one
+func LookupUserId(s string) (int, error) {
+	if s == "" {
+		return -1, nil
 	}
+	if id, err := strconv.Atoi(s); err == nil {
+		return id, nil
+	}
+	u, err := user.Lookup(s)
+	if err != nil {
+		return -1, err
+	}
+	return strconv.Atoi(u.Uid)
+}
+
+// Lookup group id by given group name or id, returns -1 for empty one
+func LookupGroupId(s string) (int, error) {
+	if s == "" {
+		return -1, nil
+	}
+	if id, err := strconv.Atoi(s); err == nil {
+		return id, nil
+	}
+	g, err := user.LookupGroup(s)
+	if err != nil {
+		return -1, err
+	}
+	return strconv.Atoi(g.Gid)
+}
+
+// Command to execute, either using system shell or directly
+type Command struct {
+	// Command line to execute using system shell
+	Line string
+	// Command arguments to execute directly, without shell
+	Args []string
+	// Additional environment variables in form 'key=value'
+	Env []string
+}
 
-	// Create command execution and get stdout/stderr
-	cmd := exec.Command(shell, flag, command)
+// Shell special characters, command lines without them can be executed directly
+const shellSpecialChars = "|&;<>()$`\\\"'*?[]#~=%{}\n"
+
+func (c Command) IsEmpty() bool {
+	return c.Line == "" && len(c.Args) == 0
+}
+
+func (c Command) String() string {
+	if len(c.Args) == 0 {
+		return c.Line
+	}
+	args := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		if arg == "" || strings.ContainsAny(arg, " \t"+shellSpecialChars) {
+			arg = strconv.Quote(arg)
+		}
+		args[i] = arg
+	}
+	return strings.Join(args, " ")
+}
+
+// Quote given string for safe use as a single word in shell command line
+func ShellQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t"+shellSpecialChars) {
+		return s
+	}
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+// Returns command key to check commands are the same. Command path is normalized,
+// if applicable, and simple command lines have the same key as argument lists.
+func (c Command) Key() string {
+	args := c.Args
+	if len(args) == 0 {
+		if strings.ContainsAny(c.Line, shellSpecialChars) {
+			return normCommandPath(c.Line)
+		}
+		args = strings.Fields(c.Line)
+		if len(args) == 0 {
+			return ""
+		}
+	}
+	key := append([]string{normCommandPath(args[0])}, args[1:]...)
+	return fmt.Sprintf("%q", key)
+}
+
+// Normalize command path, if applicable
+func normCommandPath(cmd string) string {
+	if _, err := os.Stat(cmd); err == nil {
+		if c, err := NormPath(cmd); err == nil {
+			return c
+		}
+	}
+	return cmd
+}
+
+// Command execution error
+type CommandError struct {
+	// Command executed
+	Command string
+	// Command exit code, -1 if command was killed by signal
+	ExitCode int
+	// Signal killed command, if any
+	Signal os.Signal
+	// Command execution timed out
+	TimedOut bool
+	// Command execution timeout
+	Timeout time.Duration
+}
+
+func (e *CommandError) Error() string {
+	var status string
+	if e.Signal != nil {
+		status = fmt.Sprintf("killed by signal: %v", e.Signal)
+	} else {
+		status = fmt.Sprintf("exited with code %d", e.ExitCode)
+	}
+	if e.TimedOut {
+		return fmt.Sprintf("command %q timed out (%v), %s", e.Command, e.Timeout, status)
+	}
+	return fmt.Sprintf("command %q %s", e.Command, status)
+}
+
+// Execute command with timeout, using system shell for command lines. Command is started
+// in its own process group, which is terminated on timeout, and killed if not exited
+// during given kill timeout.
+func Execute(command Command, timeout, killTimeout time.Duration) error {
+	var cmd *exec.Cmd
+	if len(command.Args) > 0 {
+		cmd = exec.Command(command.Args[0], command.Args[1:]...)
+	} else {
+		// Set shell and command execution flag
+		shell, flag := "/bin/sh", "-c"
+		if runtime.GOOS == "windows" {
+			shell, flag = "cmd", "/C"
+		}
+		cmd = exec.Command(shell, flag, command.Line)
+	}
+	setProcessGroup(cmd)
+	if len(command.Env) > 0 {
+		cmd.Env = append(os.Environ(), command.Env...)
+	}
+
+	// Get command stdout/stderr
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
 		return err
@@ -72,9 +210,6 @@ func Execute(command string, timeout time.Duration) error {
 	}
 	defer CloseQuietly(stderr)
 
-	timeoutFlag := false
-	var timeoutFlagLock sync.RWMutex
-
 	// Start command execution
 	if err := cmd.Start(); err != nil {
 		return err
@@ -82,14 +217,8 @@ func Execute(command string, timeout time.Duration) error {
 
 	// Create command result channel
 	result := make(chan error, 1)
-	defer close(result)
 	go func() {
-		err := cmd.Wait()
-		timeoutFlagLock.RLock()
-		defer timeoutFlagLock.RUnlock()
-		if !timeoutFlag {
-			result <- err
-		}
+		result <- cmd.Wait()
 	}()
 
 	// Log stdout/stderr
@@ -98,7 +227,7 @@ func Execute(command string, timeout time.Duration) error {
 		for outScanner.Scan() {
 			glog.V(4).Infof("STDOUT: %s", outScanner.Text())
 		}
-		if err := outScanner.Err(); err != nil {
+		if err := outScanner.Err(); err != nil && !errors.Is(err, os.ErrClosed) {
 			glog.Errorf("STDOUT: error: %v", err)
 		}
 	}()
@@ -107,35 +236,103 @@ func Execute(command string, timeout time.Duration) error {
 		for errScanner.Scan() {
 			glog.V(4).Infof("STDERR: %s", errScanner.Text())
 		}
-		if err := errScanner.Err(); err != nil {
+		if err := errScanner.Err(); err != nil && !errors.Is(err, os.ErrClosed) {
 			glog.Errorf("STDERR: error: %v", err)
 		}
 	}()
 
 	// Wait for result indefinitely if no timeout set
 	if timeout == 0 {
-		return <-result
+		return newCommandError(command, <-result, false, 0)
 	}
 
 	// Wait for result for given duration if timeout set
 	select {
 	case <-time.After(timeout):
-		timeoutFlagLock.Lock()
-		defer timeoutFlagLock.Unlock()
-		timeoutFlag = true
-		if cmd.Process != nil {
-			if err := cmd.Process.Kill(); err != nil {
-				glog.Errorf("timeout (%v): %q, not killed: %v", timeout, command, err)
-			} else {
-				glog.Warningf("timeout (%v): %q, killed", timeout, command)
-			}
-		} else {
-			glog.Warningf("timeout (%v): %q, nothing to kill", timeout, command)
-		}
-		return fmt.Errorf("timeout (%v): %q", timeout, command)
+		// Terminate command process group, then kill it if not exited in time
+		glog.Warningf("timeout (%v): %q, terminating", timeout, command.String())
+		if err := terminateProcessGroup(cmd); err != nil {
+			glog.Errorf("timeout (%v): %q, not terminated: %v", timeout, command.String(), err)
+		}
+		killTimer := time.NewTimer(killTimeout)
+		defer killTimer.Stop()
+		var err error
+		exited := false
+		select {
+		case err = <-result:
+			exited = true
+		case <-killTimer.C:
+		}
+		// Other process group members may outlive command process, so wait for them as well
+		if exited && waitProcessGroup(cmd, killTimer.C) {
+			return newCommandError(command, err, true, timeout)
+		}
+		glog.Warningf("timeout (%v): %q, killing", timeout, command.String())
+		if err := killProcessGroup(cmd); err != nil {
+			glog.Errorf("timeout (%v): %q, not killed: %v", timeout, command.String(), err)
+		}
+		if !exited {
+			err = <-result
+		}
+		return newCommandError(command, err, true, timeout)
 	case err := <-result:
-		return err
+		return newCommandError(command, err, false, 0)
+	}
+}
+
+// Wait for command process group to exit, returns false if it still exists when given channel fires
+func waitProcessGroup(cmd *exec.Cmd, timeout <-chan time.Time) bool {
+	for processGroupExists(cmd) {
+		select {
+		case <-timeout:
+			return false
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	return true
+}
+
+// Create command execution error from given command wait error, if any
+func newCommandError(command Command, err error, timedOut bool, timeout time.Duration) error {
+	if err == nil && !timedOut {
+		return nil
+	}
+	cmdErr := &CommandError{
+		Command:  command.String(),
+		TimedOut: timedOut,
+		Timeout:  timeout,
+	}
+	if err != nil {
+		exitErr, ok := err.(*exec.ExitError)
+		if !ok {
+			return err
+		}
+		cmdErr.ExitCode = exitErr.ExitCode()
+		cmdErr.Signal = exitSignal(exitErr.ProcessState)
+	}
+	return cmdErr
+}
+
+// Parse signal given by name (with or without 'SIG' prefix) or number
+func ParseSignal(s string) (os.Signal, error) {
+	name := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "SIG")
+	if sig, found := signals[name]; found {
+		return sig, nil
+	}
+	if n, err := strconv.Atoi(name); err == nil && n > 0 {
+		return syscall.Signal(n), nil
+	}
+	return nil, fmt.Errorf("unknown signal: %s", s)
+}
+
+// Returns signal name in the format 'SIGNAME', or its description if name is unknown
+func SignalName(sig os.Signal) string {
+	for name, s := range signals {
+		if s == sig {
+			return "SIG" + name
+		}
 	}
+	return sig.String()
 }
 
 // Close given closer without error checking
@@ -3,8 +3,11 @@ package main
 import (
 	"flag"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 var update = flag.Bool("update", false, "update .golden files")
@@ -24,3 +27,63 @@ func TestIsPresent(t *testing.T) {
 	assert.False(t, IsPresent([]string{}, "a"))
 	assert.False(t, IsPresent([]string{"d", "e", "f"}, "a"))
 }
+
+func TestLookupIds(t *testing.T) {
+	id, err := LookupUserId("")
+	assert.NoError(t, err)
+	assert.Equal(t, -1, id)
+
+	id, err = LookupUserId("1000")
+	assert.NoError(t, err)
+	assert.Equal(t, 1000, id)
+
+	id, err = LookupGroupId("")
+	assert.NoError(t, err)
+	assert.Equal(t, -1, id)
+
+	id, err = LookupGroupId("1000")
+	assert.NoError(t, err)
+	assert.Equal(t, 1000, id)
+
+	_, err = LookupUserId("no-such-user-kube-template")
+	assert.Error(t, err)
+}
+
+func TestCommandKey(t *testing.T) {
+	assert.Equal(t, Command{Line: "systemctl reload nginx"}.Key(),
+		Command{Args: []string{"systemctl", "reload", "nginx"}}.Key())
+	assert.Equal(t, Command{Line: "  systemctl   reload nginx "}.Key(),
+		Command{Line: "systemctl reload nginx"}.Key())
+	assert.NotEqual(t, Command{Line: "systemctl reload nginx"}.Key(),
+		Command{Args: []string{"systemctl", "reload nginx"}}.Key())
+	assert.Equal(t, "echo $HOME", Command{Line: "echo $HOME"}.Key())
+	assert.NotEqual(t, Command{Line: "echo $HOME"}.Key(), Command{Args: []string{"echo", "$HOME"}}.Key())
+}
+
+func TestCommandString(t *testing.T) {
+	assert.Equal(t, "service nginx reload", Command{Line: "service nginx reload"}.String())
+	assert.Equal(t, `echo "a b" "$HOME" ""`, Command{Args: []string{"echo", "a b", "$HOME", ""}}.String())
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, "/etc/nginx/nginx.conf", ShellQuote("/etc/nginx/nginx.conf"))
+	assert.Equal(t, "'/tmp/my dir/out.txt'", ShellQuote("/tmp/my dir/out.txt"))
+	assert.Equal(t, `'a;b'\''c'`, ShellQuote("a;b'c"))
+	assert.Equal(t, "''", ShellQuote(""))
+}
+
+func TestExecute(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kube-template")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Arguments are passed as is, without shell expansion
+	out := filepath.Join(dir, "out $HOME;")
+	assert.NoError(t, Execute(Command{Args: []string{"touch", out}}, time.Second, time.Second))
+	_, err = os.Stat(out)
+	assert.NoError(t, err)
+
+	assert.NoError(t, Execute(Command{Line: "test -f '" + out + "'"}, time.Second, time.Second))
+	assert.Error(t, Execute(Command{Args: []string{"false"}}, time.Second, time.Second))
+	assert.Error(t, Execute(Command{Line: "exit 1"}, time.Second, time.Second))
+}
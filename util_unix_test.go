@@ -0,0 +1,94 @@
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Check process with given pid is running (not exited or zombie)
+func isProcessRunning(pid string) bool {
+	stat, err := ioutil.ReadFile(filepath.Join("/proc", pid, "stat"))
+	if err != nil {
+		return false
+	}
+	fields := strings.Fields(string(stat))
+	return len(fields) > 2 && fields[2] != "Z"
+}
+
+func TestExecuteExitCode(t *testing.T) {
+	err := Execute(Command{Line: "exit 3"}, time.Second, time.Second)
+	require.IsType(t, &CommandError{}, err)
+	cmdErr := err.(*CommandError)
+	require.Equal(t, 3, cmdErr.ExitCode)
+	require.False(t, cmdErr.TimedOut)
+	require.Nil(t, cmdErr.Signal)
+}
+
+func TestExecuteTimeoutTerminatesProcessGroup(t *testing.T) {
+	if _, err := os.Stat("/proc/self/stat"); err != nil {
+		t.Skip("procfs is not available")
+	}
+
+	dir, err := ioutil.TempDir("", "kube-template")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	pidFile := filepath.Join(dir, "pid")
+	err = Execute(Command{Line: "sleep 30 & echo $! > " + pidFile + "; wait"}, 500*time.Millisecond, 5*time.Second)
+	require.IsType(t, &CommandError{}, err)
+	cmdErr := err.(*CommandError)
+	require.True(t, cmdErr.TimedOut)
+	require.Equal(t, syscall.SIGTERM, cmdErr.Signal)
+
+	pid, err := ioutil.ReadFile(pidFile)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return !isProcessRunning(strings.TrimSpace(string(pid)))
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestExecuteTimeoutKillsProcessGroup(t *testing.T) {
+	start := time.Now()
+	err := Execute(Command{Line: "trap '' TERM; sleep 30"}, 200*time.Millisecond, 200*time.Millisecond)
+	require.IsType(t, &CommandError{}, err)
+	cmdErr := err.(*CommandError)
+	require.True(t, cmdErr.TimedOut)
+	require.Equal(t, syscall.SIGKILL, cmdErr.Signal)
+	require.Equal(t, -1, cmdErr.ExitCode)
+	require.True(t, time.Since(start) < 5*time.Second)
+}
+
+func TestExecuteTimeoutKillsProcessGroupMembers(t *testing.T) {
+	if _, err := os.Stat("/proc/self/stat"); err != nil {
+		t.Skip("procfs is not available")
+	}
+
+	dir, err := ioutil.TempDir("", "kube-template")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Command process exits on SIGTERM, but its child ignores it
+	pidFile := filepath.Join(dir, "pid")
+	start := time.Now()
+	err = Execute(Command{Line: `sh -c "trap '' TERM; sleep 30" & echo $! > ` + pidFile + "; wait"},
+		200*time.Millisecond, 200*time.Millisecond)
+	require.IsType(t, &CommandError{}, err)
+	require.True(t, err.(*CommandError).TimedOut)
+	require.True(t, time.Since(start) < 5*time.Second)
+
+	pid, err := ioutil.ReadFile(pidFile)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return !isProcessRunning(strings.TrimSpace(string(pid)))
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,65 @@
+// Copyright © 2015 Victor Antonovich <victor@antonovich.me>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// Signals by names
+var signals = map[string]syscall.Signal{
+	"HUP":  syscall.SIGHUP,
+	"INT":  syscall.SIGINT,
+	"QUIT": syscall.SIGQUIT,
+	"KILL": syscall.SIGKILL,
+	"TERM": syscall.SIGTERM,
+}
+
+// Signals forwarded to child process in exec mode, besides termination ones
+var forwardedSignals []os.Signal
+
+// Find running process with given id
+func findProcess(pid int) (*os.Process, error) {
+	return os.FindProcess(pid)
+}
+
+// Process groups are not supported, command process only is managed
+func setProcessGroup(_ *exec.Cmd) {
+}
+
+// Processes can't be terminated gracefully, so kill command process
+func terminateProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
+
+// Kill command process
+func killProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
+
+// Process groups are not supported, so there are no processes left after command process exit
+func processGroupExists(_ *exec.Cmd) bool {
+	return false
+}
+
+// Signals are not supported
+func exitSignal(_ *os.ProcessState) os.Signal {
+	return nil
+}
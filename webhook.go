@@ -0,0 +1,226 @@
+// Copyright © 2015 Victor Antonovich <victor@antonovich.me>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	gotemplate "text/template"
+	"time"
+
+	"golang.org/x/net/http/httpguts"
+)
+
+// Default webhook request method
+const DefaultWebhookMethod = http.MethodPost
+
+// Webhook request timeout if neither webhook nor command timeout is set
+const DefaultWebhookTimeout = 30 * time.Second
+
+// Maximum length of webhook response body to report on request failure
+const maxWebhookErrorBody = 512
+
+// Action to send HTTP request after template outputs updating
+type WebhookAction struct {
+	// Request URL
+	URL string
+	// Request method
+	Method string
+	// Additional request headers
+	Headers map[string]string
+	// Request body, as a template
+	Body string
+	// Request timeout, command timeout (or default webhook timeout if not set) is used if zero
+	Timeout time.Duration
+
+	body *gotemplate.Template
+}
+
+// Webhook request body template data
+type webhookData struct {
+	// Templates updated
+	Templates []webhookTemplate
+}
+
+// Updated template description for webhook request body
+type webhookTemplate struct {
+	// Template name
+	Name string `json:"name"`
+	// Template path
+	Path string `json:"path"`
+	// Template output path
+	Output string `json:"output"`
+	// SHA-256 checksum of template output, in hex
+	Checksum string `json:"checksum"`
+}
+
+// Parse webhook action in the format '{url: url, method: method, headers: {name: value}, body: template, timeout: duration}'
+func parseWebhook(v interface{}) (*WebhookAction, error) {
+	m, ok := v.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid webhook value: %v", v)
+	}
+	w := &WebhookAction{Method: DefaultWebhookMethod}
+	for ik, iv := range m {
+		switch k := fmt.Sprint(ik); k {
+		case "url":
+			w.URL = fmt.Sprint(iv)
+		case "method":
+			w.Method = strings.ToUpper(fmt.Sprint(iv))
+		case "headers":
+			headers, err := parseHeaders(iv)
+			if err != nil {
+				return nil, err
+			}
+			w.Headers = headers
+		case "body":
+			w.Body = fmt.Sprint(iv)
+		case "timeout":
+			d, err := parseDuration(iv)
+			if err != nil {
+				return nil, fmt.Errorf("invalid webhook timeout: %v", err)
+			}
+			w.Timeout = d
+		default:
+			return nil, fmt.Errorf("unknown webhook option: %s", k)
+		}
+	}
+	if w.URL == "" {
+		return nil, errors.New("webhook url is not set")
+	}
+	if u, err := url.Parse(w.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid webhook url: %s", w.URL)
+	}
+	if err := w.init(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Parse webhook request headers map, checking header names and values are valid
+func parseHeaders(v interface{}) (map[string]string, error) {
+	headers := make(map[string]string)
+	switch m := v.(type) {
+	case map[string]interface{}:
+		for k, v := range m {
+			headers[k] = fmt.Sprint(v)
+		}
+	case map[interface{}]interface{}:
+		for k, v := range m {
+			headers[fmt.Sprint(k)] = fmt.Sprint(v)
+		}
+	default:
+		return nil, fmt.Errorf("invalid webhook headers value: %v", v)
+	}
+	for name, value := range headers {
+		if !httpguts.ValidHeaderFieldName(name) {
+			return nil, fmt.Errorf("invalid webhook header name: %q", name)
+		}
+		if !httpguts.ValidHeaderFieldValue(value) {
+			return nil, fmt.Errorf("invalid webhook header %s value: %q", name, value)
+		}
+	}
+	return headers, nil
+}
+
+// Parse webhook request body template
+func (w *WebhookAction) init() error {
+	body, err := gotemplate.New("webhook").Funcs(gotemplate.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(w.Body)
+	if err != nil {
+		return fmt.Errorf("invalid webhook body: %v", err)
+	}
+	w.body = body
+	return nil
+}
+
+// Returns key to check webhook actions are the same
+func (w *WebhookAction) Key() string {
+	headers := envList(w.Headers)
+	return fmt.Sprintf("webhook %s %s %q %q", w.Method, w.URL, headers, w.Body)
+}
+
+// Returns webhook request method and URL
+func (w *WebhookAction) String() string {
+	return w.Method + " " + w.URL
+}
+
+// Send webhook request with body rendered for templates updated, checking for successful response
+func (w *WebhookAction) Execute(ctx *ActionContext) error {
+	if w.body == nil {
+		if err := w.init(); err != nil {
+			return err
+		}
+	}
+	var data webhookData
+	for _, t := range ctx.Templates {
+		checksum := sha256.Sum256([]byte(t.lastOutput))
+		data.Templates = append(data.Templates, webhookTemplate{
+			Name:     t.name,
+			Path:     t.desc.Path,
+			Output:   t.desc.Output,
+			Checksum: hex.EncodeToString(checksum[:]),
+		})
+	}
+	body := new(bytes.Buffer)
+	if err := w.body.Execute(body, data); err != nil {
+		return fmt.Errorf("can't render webhook body: %v", err)
+	}
+
+	req, err := http.NewRequest(w.Method, w.URL, body)
+	if err != nil {
+		return err
+	}
+	for name, value := range w.Headers {
+		req.Header.Set(name, value)
+	}
+
+	client := &http.Client{Timeout: w.timeout(ctx)}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer CloseQuietly(resp.Body)
+	respBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxWebhookErrorBody))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s: %s: %s", w, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+// Returns webhook request timeout, never zero to not block templates processing
+func (w *WebhookAction) timeout(ctx *ActionContext) time.Duration {
+	switch {
+	case w.Timeout > 0:
+		return w.Timeout
+	case ctx.Timeout > 0:
+		return ctx.Timeout
+	default:
+		return DefaultWebhookTimeout
+	}
+}
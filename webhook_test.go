@@ -0,0 +1,105 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseWebhook(t *testing.T) {
+	w, err := parseWebhook(map[interface{}]interface{}{
+		"url":     "http://localhost:9901/reload",
+		"headers": map[interface{}]interface{}{"Authorization": "Bearer token"},
+		"timeout": "5s",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "POST http://localhost:9901/reload", w.String())
+	require.Equal(t, map[string]string{"Authorization": "Bearer token"}, w.Headers)
+	require.Equal(t, 5*time.Second, w.Timeout)
+
+	_, err = parseWebhook(map[interface{}]interface{}{"method": "PUT"})
+	require.Error(t, err)
+
+	_, err = parseWebhook(map[interface{}]interface{}{"url": "localhost:9901"})
+	require.Error(t, err)
+
+	_, err = parseWebhook(map[interface{}]interface{}{"url": "http://localhost", "body": "{{.Templates"})
+	require.Error(t, err)
+
+	_, err = parseWebhook(map[interface{}]interface{}{"url": "http://localhost",
+		"headers": map[interface{}]interface{}{"X Token": "token"}})
+	require.EqualError(t, err, `invalid webhook header name: "X Token"`)
+
+	_, err = parseWebhook(map[interface{}]interface{}{"url": "http://localhost",
+		"headers": map[interface{}]interface{}{"X-Token": "a\nb"}})
+	require.Error(t, err)
+}
+
+func TestWebhookTimeout(t *testing.T) {
+	require.Equal(t, 5*time.Second, (&WebhookAction{Timeout: 5 * time.Second}).timeout(&ActionContext{Timeout: time.Second}))
+	require.Equal(t, time.Second, (&WebhookAction{}).timeout(&ActionContext{Timeout: time.Second}))
+	// Command timeout checking is disabled
+	require.Equal(t, DefaultWebhookTimeout, (&WebhookAction{}).timeout(&ActionContext{}))
+}
+
+func TestAppWebhook(t *testing.T) {
+	f := newTestFixture(t, fake.NewSimpleClientset(), false)
+	defer f.Close()
+
+	var mu sync.Mutex
+	var requests []string
+	fail := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		// First request fails
+		if fail {
+			fail = false
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, fmt.Sprintf("%s %s %s %s", r.Method, r.URL.Path, r.Header.Get("X-Token"), body))
+	}))
+	defer srv.Close()
+
+	var templates []*Template
+	for i := 0; i < 2; i++ {
+		webhook, err := parseWebhook(map[interface{}]interface{}{
+			"url":     srv.URL + "/reload",
+			"method":  "put",
+			"headers": map[interface{}]interface{}{"X-Token": "secret"},
+			"body":    `{{range .Templates}}{{.Name}}={{.Checksum}};{{end}}`,
+		})
+		require.NoError(t, err)
+		templates = append(templates, f.newTemplate(fmt.Sprintf("test%d", i), &TemplateDescriptor{
+			Webhook: webhook,
+			Retries: 1,
+			Backoff: 10 * time.Millisecond,
+		}))
+	}
+
+	app := f.newApp(templates...)
+	app.runOnce = true
+
+	app.RunOnce()
+	require.Empty(t, app.pending)
+
+	checksum := func(s string) string {
+		c := sha256.Sum256([]byte(s))
+		return hex.EncodeToString(c[:])
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []string{fmt.Sprintf("PUT /reload secret 0.tmpl=%s;1.tmpl=%s;",
+		checksum("test0"), checksum("test1"))}, requests)
+}